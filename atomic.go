@@ -0,0 +1,73 @@
+package trit
+
+import "sync/atomic"
+
+// Atomic is a Trit that is safe for concurrent use without a mutex. It is
+// backed by an atomic.Int32 holding the canonical -1/0/1 value.
+//
+// The zero value is an Unknown Atomic ready to use. An Atomic must not be
+// copied after first use.
+//
+// Example usage:
+//
+//	var ready trit.Atomic
+//	go func() { ready.ResolveOnce(trit.True) }()
+//	if ready.Load().IsTrue() {
+//		// ...
+//	}
+type Atomic struct {
+	v atomic.Int32
+}
+
+// NewAtomic returns a new Atomic holding v.
+func NewAtomic(v Trit) *Atomic {
+	a := new(Atomic)
+	a.Store(v)
+	return a
+}
+
+// Load atomically loads and returns the stored value.
+func (a *Atomic) Load() Trit {
+	return Trit(a.v.Load())
+}
+
+// Store atomically stores v, normalized to its canonical value.
+func (a *Atomic) Store(v Trit) {
+	a.v.Store(int32(v.Val()))
+}
+
+// Swap atomically stores v and returns the previous value.
+func (a *Atomic) Swap(v Trit) Trit {
+	return Trit(a.v.Swap(int32(v.Val())))
+}
+
+// CompareAndSwap executes the compare-and-swap operation for the value.
+// Both old and new are normalized first, so any negative Trit matches a
+// stored False and any positive Trit matches a stored True.
+func (a *Atomic) CompareAndSwap(old, new Trit) bool {
+	return a.v.CompareAndSwap(int32(old.Val()), int32(new.Val()))
+}
+
+// ResolveOnce atomically replaces an Unknown value with v and reports whether
+// this call made the transition. It is the concurrent counterpart of
+// Trit.Default: once the value is known, every later call loses and leaves
+// it untouched. Resolving to Unknown is a no-op that always returns false.
+//
+// Example usage:
+//
+//	var decision trit.Atomic
+//	if decision.ResolveOnce(trit.False) {
+//		// This goroutine decided; the others will see False.
+//	}
+func (a *Atomic) ResolveOnce(v Trit) bool {
+	if v.IsUnknown() {
+		return false
+	}
+
+	return a.v.CompareAndSwap(int32(Unknown), int32(v.Val()))
+}
+
+// String returns the String form of the current value.
+func (a *Atomic) String() string {
+	return a.Load().String()
+}
@@ -0,0 +1,125 @@
+package trit
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestAtomicBasic covers the single-goroutine contract, including the
+// normalization of non-canonical inputs.
+func TestAtomicBasic(t *testing.T) {
+	var a Atomic
+	if a.Load() != Unknown || a.String() != "Unknown" {
+		t.Fatalf("zero Atomic = %s, want Unknown", a.Load())
+	}
+
+	a.Store(Trit(9))
+	if a.Load() != True {
+		t.Errorf("Store(9) -> %s, want True", a.Load())
+	}
+	if old := a.Swap(Trit(-9)); old != True || a.Load() != False {
+		t.Errorf("Swap = %s (now %s), want True (now False)", old, a.Load())
+	}
+
+	if a.CompareAndSwap(True, Unknown) {
+		t.Errorf("CompareAndSwap must fail on mismatch")
+	}
+	if !a.CompareAndSwap(Trit(-3), True) || a.Load() != True {
+		t.Errorf("CompareAndSwap must normalize old, now %s", a.Load())
+	}
+
+	if NewAtomic(False).Load() != False {
+		t.Errorf("NewAtomic(False) mismatch")
+	}
+}
+
+// TestAtomicResolveOnce checks the Unknown -> known transition rules.
+func TestAtomicResolveOnce(t *testing.T) {
+	var a Atomic
+	if a.ResolveOnce(Unknown) || a.Load() != Unknown {
+		t.Errorf("resolving to Unknown must be a no-op")
+	}
+	if !a.ResolveOnce(False) || a.Load() != False {
+		t.Errorf("first ResolveOnce must win, got %s", a.Load())
+	}
+	if a.ResolveOnce(True) || a.Load() != False {
+		t.Errorf("second ResolveOnce must lose, got %s", a.Load())
+	}
+
+	// Parity with Trit.Default for every start/argument pair.
+	for _, start := range canonical {
+		for _, v := range canonical {
+			a := NewAtomic(start)
+			a.ResolveOnce(v)
+			want := start
+			want.Default(v)
+			if a.Load() != want {
+				t.Errorf("ResolveOnce(%s) from %s = %s, Default gives %s",
+					v, start, a.Load(), want)
+			}
+		}
+	}
+}
+
+// TestAtomicConcurrent races many goroutines on ResolveOnce and on the
+// read/write operations; run with -race. Exactly one resolver must win and
+// every reader must only ever observe canonical values.
+func TestAtomicConcurrent(t *testing.T) {
+	const n = 64
+
+	var (
+		a    Atomic
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		wins []Trit
+	)
+	for i := range n {
+		v := True
+		if i%2 == 0 {
+			v = False
+		}
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if a.ResolveOnce(v) {
+				mu.Lock()
+				wins = append(wins, v)
+				mu.Unlock()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if got := a.Load(); !isCanonical(got) {
+				t.Errorf("Load observed non-canonical %d", int8(got))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(wins) != 1 || a.Load() != wins[0] {
+		t.Fatalf("winners = %v, final %s; want exactly one matching winner",
+			wins, a.Load())
+	}
+
+	// Concurrent Store/Swap/CompareAndSwap must keep the value canonical.
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch i % 3 {
+			case 0:
+				a.Store(Trit(int8(i - n/2)))
+			case 1:
+				a.Swap(Trit(int8(n/2 - i)))
+			default:
+				a.CompareAndSwap(a.Load(), Unknown)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !isCanonical(a.Load()) {
+		t.Errorf("final value non-canonical %d", int8(a.Load()))
+	}
+}
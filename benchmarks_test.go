@@ -163,3 +163,25 @@ func BenchmarkExtendedOperations(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkBitmap compares counting a 64k-element column through its Arrow
+// bitmaps with converting it element by element.
+func BenchmarkBitmap(b *testing.B) {
+	s := make(Slice, 1<<16)
+	for i := range s {
+		s[i] = Trit(i%3 - 1)
+	}
+	col := s.Bitmap()
+
+	b.Run("Tally", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = col.Tally()
+		}
+	})
+
+	b.Run("Trits", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Majority(col.Trits()...)
+		}
+	})
+}
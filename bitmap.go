@@ -0,0 +1,218 @@
+package trit
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// ErrShortBitmap is returned by FromBitmaps when a buffer holds fewer bits
+// than the requested length.
+var ErrShortBitmap = errors.New("bitmap shorter than its length")
+
+// Bitmap is a read-only view of a column of nullable booleans in the layout
+// of Apache Arrow (and Parquet readers): a validity bitmap, whose cleared
+// bits mark NULLs, and a value bitmap. Bit i of a bitmap is bit i%8 of byte
+// i/8 (least significant bit first). Element i is Unknown where its
+// validity bit is 0, whatever its value bit, and otherwise True or False
+// by its value bit.
+//
+// A Bitmap shares the buffers it was made from: creating and slicing it
+// copies nothing, and Tally counts the three states a machine word at a
+// time, so analytics columns feed the aggregates without converting each
+// element. The buffers must not change while the Bitmap is in use.
+type Bitmap struct {
+	validity, values []byte // validity nil means every element is valid
+	offset, n        int
+}
+
+// FromBitmaps returns a view of n elements over the given buffers, starting
+// at bit 0. A nil validity means no element is NULL, as Arrow allows when
+// the null count is zero. It returns ErrShortBitmap if a buffer has fewer
+// than n bits.
+//
+// Example usage:
+//
+//	col, err := trit.FromBitmaps(validity, values, rows)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(col.Tally().Majority())
+func FromBitmaps(validity, values []byte, n int) (Bitmap, error) {
+	need := (max(n, 0) + 7) / 8
+	if len(values) < need || validity != nil && len(validity) < need {
+		return Bitmap{}, fmt.Errorf("%w: %d bits need %d bytes", ErrShortBitmap, n, need)
+	}
+
+	return Bitmap{validity: validity, values: values, n: max(n, 0)}, nil
+}
+
+// Len returns the number of elements.
+func (b Bitmap) Len() int {
+	return b.n
+}
+
+// At returns element i. It panics if i is out of range.
+func (b Bitmap) At(i int) Trit {
+	if i < 0 || i >= b.n {
+		panic(fmt.Sprintf("trit: Bitmap index %d out of range [0:%d]", i, b.n))
+	}
+
+	i += b.offset
+	if b.validity != nil && b.validity[i/8]&(1<<(i%8)) == 0 {
+		return Unknown
+	}
+	if b.values[i/8]&(1<<(i%8)) == 0 {
+		return False
+	}
+
+	return True
+}
+
+// Slice returns a view of length elements starting at element offset, like
+// slicing an Arrow array. It copies nothing and panics if the range is out
+// of bounds.
+func (b Bitmap) Slice(offset, length int) Bitmap {
+	if offset < 0 || length < 0 || offset+length > b.n {
+		panic(fmt.Sprintf("trit: Bitmap slice [%d:+%d] out of range [0:%d]",
+			offset, length, b.n))
+	}
+
+	b.offset += offset
+	b.n = length
+
+	return b
+}
+
+// All returns the elements in order, for use with the …Seq aggregates.
+func (b Bitmap) All() iter.Seq[Trit] {
+	return func(yield func(Trit) bool) {
+		for i := range b.n {
+			if !yield(b.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Tally counts the True, False and Unknown elements with population counts
+// over whole words, so it costs about one operation per 64 elements.
+//
+// Example usage:
+//
+//	t := col.Tally()
+//	fmt.Println(t.Majority(), t.Consensus())
+func (b Bitmap) Tally() Tally {
+	valid, trues := 0, 0
+	for i, end := b.offset, b.offset+b.n; i < end; {
+		j := i / 8
+		switch {
+		case i%8 == 0 && end-i >= 64:
+			v := ^uint64(0)
+			if b.validity != nil {
+				v = binary.LittleEndian.Uint64(b.validity[j:])
+			}
+			valid += bits.OnesCount64(v)
+			trues += bits.OnesCount64(v & binary.LittleEndian.Uint64(b.values[j:]))
+			i += 64
+		case i%8 == 0 && end-i >= 8:
+			v := uint8(0xff)
+			if b.validity != nil {
+				v = b.validity[j]
+			}
+			valid += bits.OnesCount8(v)
+			trues += bits.OnesCount8(v & b.values[j])
+			i += 8
+		default:
+			switch b.At(i - b.offset) {
+			case True:
+				valid++
+				trues++
+			case False:
+				valid++
+			}
+			i++
+		}
+	}
+
+	return Tally{trues: trues, falses: valid - trues, unknowns: b.n - valid}
+}
+
+// Trits returns the elements as a new Slice.
+func (b Bitmap) Trits() Slice {
+	s := make(Slice, b.n)
+	for i := range s {
+		s[i] = b.At(i)
+	}
+
+	return s
+}
+
+// ToBitmaps returns the validity and value bitmaps of the elements,
+// starting at bit 0. When the view starts on a byte boundary the results
+// share the underlying buffers (the bits past the end of the view in the
+// last byte are then whatever the buffers hold); otherwise they are
+// shifted copies. validity is nil if the view was made without one.
+func (b Bitmap) ToBitmaps() (validity, values []byte) {
+	size := (b.n + 7) / 8
+	if b.offset%8 == 0 {
+		start := b.offset / 8
+		if b.validity != nil {
+			validity = b.validity[start : start+size : start+size]
+		}
+
+		return validity, b.values[start : start+size : start+size]
+	}
+
+	values = make([]byte, size)
+	if b.validity != nil {
+		validity = make([]byte, size)
+	}
+	for i := range b.n {
+		k := b.offset + i
+		if validity != nil && b.validity[k/8]&(1<<(k%8)) != 0 {
+			validity[i/8] |= 1 << (i % 8)
+		}
+		if b.values[k/8]&(1<<(k%8)) != 0 {
+			values[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return validity, values
+}
+
+// ToBitmaps returns the validity and value bitmaps of s in the Arrow layout
+// (see Bitmap). The value bit of an Unknown element is 0, and validity is
+// nil if no element is Unknown.
+func (s Slice) ToBitmaps() (validity, values []byte) {
+	size := (len(s) + 7) / 8
+	values = make([]byte, size)
+	for i, v := range s {
+		switch v.Val() {
+		case True:
+			values[i/8] |= 1 << (i % 8)
+		case Unknown:
+			if validity == nil {
+				validity = make([]byte, size)
+				for j := range i {
+					validity[j/8] |= 1 << (j % 8)
+				}
+			}
+			continue
+		}
+
+		if validity != nil {
+			validity[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return validity, values
+}
+
+// Bitmap returns a Bitmap view of s, backed by the bitmaps of ToBitmaps.
+func (s Slice) Bitmap() Bitmap {
+	validity, values := s.ToBitmaps()
+	return Bitmap{validity: validity, values: values, n: len(s)}
+}
@@ -0,0 +1,143 @@
+package trit
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestBitmapLayout pins the Arrow bit order with a hand-made column: the
+// validity of [True, NULL, False, True] is 0b1101 and the value bits of
+// valid elements are read least significant bit first. The value bit under
+// the NULL is set on purpose: Arrow leaves it undefined.
+func TestBitmapLayout(t *testing.T) {
+	b, err := FromBitmaps([]byte{0b1101}, []byte{0b1011}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Slice{True, Unknown, False, True}
+	if got := b.Trits(); !slices.Equal(got, want) {
+		t.Errorf("Trits = %v, want %v", got, want)
+	}
+	if got := slices.Collect(b.All()); !slices.Equal(got, want) {
+		t.Errorf("All = %v, want %v", got, want)
+	}
+
+	validity, values := want.ToBitmaps()
+	if !slices.Equal(validity, []byte{0b1101}) || !slices.Equal(values, []byte{0b1001}) {
+		t.Errorf("ToBitmaps = %08b %08b", validity, values)
+	}
+
+	allValid, err := FromBitmaps(nil, []byte{0b10}, 2)
+	if err != nil || allValid.At(0) != False || allValid.At(1) != True {
+		t.Errorf("nil validity must mean all valid: %v %v", allValid.Trits(), err)
+	}
+	if v, _ := (Slice{True, False}).ToBitmaps(); v != nil {
+		t.Errorf("validity of a column without Unknown must be nil, got %08b", v)
+	}
+
+	if _, err := FromBitmaps([]byte{0}, []byte{0, 0}, 9); !errors.Is(err, ErrShortBitmap) {
+		t.Errorf("short validity: err = %v", err)
+	}
+	if _, err := FromBitmaps(nil, nil, 1); !errors.Is(err, ErrShortBitmap) {
+		t.Errorf("short values: err = %v", err)
+	}
+}
+
+// randomColumn returns a random column of n elements with its bitmaps,
+// putting random garbage under the NULLs.
+func randomColumn(r *rand.Rand, n int) (Slice, []byte, []byte) {
+	s := make(Slice, n)
+	for i := range s {
+		s[i] = Trit(r.IntN(3) - 1)
+	}
+
+	validity, values := s.Bitmap().ToBitmaps()
+	if validity == nil {
+		validity = slices.Repeat([]byte{0xff}, (n+7)/8)
+	}
+	for i, v := range s {
+		if v == Unknown && r.IntN(2) == 0 {
+			values[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return s, validity, values
+}
+
+// TestBitmapSlicing checks every offset and length of random columns:
+// slicing, Tally and ToBitmaps must agree with the element-wise results.
+func TestBitmapSlicing(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for _, n := range []int{0, 1, 7, 8, 9, 63, 64, 65, 200} {
+		s, validity, values := randomColumn(r, n)
+		b, err := FromBitmaps(validity, values, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for off := 0; off <= n; off++ {
+			for _, length := range []int{0, 1, 8, 64, 70, n - off} {
+				if off+length > n {
+					continue
+				}
+
+				v := b.Slice(off, length)
+				want := s[off : off+length]
+				if got := v.Trits(); !slices.Equal(got, want) {
+					t.Fatalf("n=%d [%d:+%d] Trits = %v, want %v", n, off, length, got, want)
+				}
+
+				var tally Tally
+				for _, x := range want {
+					tally.Add(x)
+				}
+				if got := v.Tally(); got != tally {
+					t.Fatalf("n=%d [%d:+%d] Tally = %+v, want %+v", n, off, length, got, tally)
+				}
+
+				bv, bx := v.ToBitmaps()
+				back, err := FromBitmaps(bv, bx, length)
+				if err != nil || !slices.Equal(back.Trits(), want) {
+					t.Fatalf("n=%d [%d:+%d] ToBitmaps round-trip = %v, %v",
+						n, off, length, back.Trits(), err)
+				}
+			}
+		}
+	}
+}
+
+// TestBitmapZeroCopy checks that byte-aligned views share their buffers.
+func TestBitmapZeroCopy(t *testing.T) {
+	validity := []byte{0xff, 0xff, 0xff}
+	values := []byte{0x00, 0x0f, 0x00}
+	b, _ := FromBitmaps(validity, values, 24)
+
+	v, x := b.Slice(8, 8).ToBitmaps()
+	if &v[0] != &validity[1] || &x[0] != &values[1] {
+		t.Errorf("byte-aligned ToBitmaps must not copy")
+	}
+
+	values[1] = 0xff // the view observes changes to the buffers
+	if b.Slice(8, 8).Tally().Consensus() != True {
+		t.Errorf("view must share the value buffer")
+	}
+}
+
+// TestBitmapAggregates feeds a bitmap to the aggregates.
+func TestBitmapAggregates(t *testing.T) {
+	b := Slice{True, True, Unknown, False, True}.Bitmap()
+	if b.Tally().Majority() != True || AndAllSeq(b.All()) != False ||
+		KnownSeq(b.All()) != False {
+		t.Errorf("aggregates over a bitmap disagree")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("At out of range must panic")
+		}
+	}()
+	b.At(5)
+}
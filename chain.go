@@ -0,0 +1,63 @@
+package trit
+
+// Layer is a named value in a Chain, such as the "team" level of a setting.
+type Layer struct {
+	Name  string
+	Value Trit
+}
+
+// Chain is an ordered list of layers, highest precedence first. It resolves
+// to the value of the first layer that is not Unknown — the same rule as
+// Coalesce — but also reports which layer decided, which is what an audit
+// log needs to explain why a feature is on or off.
+//
+// Example usage:
+//
+//	c := trit.Chain{
+//		{Name: "user", Value: trit.Unknown},
+//		{Name: "team", Value: trit.False},
+//		{Name: "global", Value: trit.True},
+//	}
+//	v, layer := c.Resolve()
+//	fmt.Println(v, layer) // Output: False team
+type Chain []Layer
+
+// Resolve returns the value of the first definite layer together with its
+// name. If every layer is Unknown (or the chain is empty) it returns Unknown
+// and an empty name.
+func (c Chain) Resolve() (Trit, string) {
+	if i := c.Index(); i >= 0 {
+		return c[i].Value.Val(), c[i].Name
+	}
+
+	return Unknown, ""
+}
+
+// Index returns the position of the layer that decides the chain, or -1 if
+// every layer is Unknown. Unlike the name returned by Resolve, the index is
+// unambiguous even when layers share a name or have none.
+func (c Chain) Index() int {
+	for i, l := range c {
+		if !l.Value.IsUnknown() {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Value returns the resolved value of the chain. It is equivalent to the
+// first result of Resolve.
+func (c Chain) Value() Trit {
+	v, _ := c.Resolve()
+	return v
+}
+
+// Add returns a new chain extended with a layer of lower precedence than all
+// existing ones; c itself is not modified, so several chains can be built
+// from a common base. It allows a chain to be built fluently:
+//
+//	v, layer := trit.Chain{}.Add("user", u).Add("org", o).Resolve()
+func (c Chain) Add(name string, v Trit) Chain {
+	return append(c[:len(c):len(c)], Layer{Name: name, Value: v})
+}
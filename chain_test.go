@@ -0,0 +1,60 @@
+package trit
+
+import "testing"
+
+// TestChainResolve checks the value and the deciding layer, including the
+// all-Unknown and empty cases.
+func TestChainResolve(t *testing.T) {
+	c := Chain{
+		{Name: "user", Value: Unknown},
+		{Name: "team", Value: Unknown},
+		{Name: "org", Value: Trit(-4)},
+		{Name: "global", Value: True},
+	}
+	v, layer := c.Resolve()
+	if v != False || layer != "org" || c.Index() != 2 {
+		t.Errorf("Resolve = %s, %q (index %d); want False, \"org\" (2)",
+			v, layer, c.Index())
+	}
+	if c.Value() != False {
+		t.Errorf("Value = %s, want False", c.Value())
+	}
+
+	for _, empty := range []Chain{nil, {}, {{Name: "user"}, {Name: "org"}}} {
+		v, layer := empty.Resolve()
+		if v != Unknown || layer != "" || empty.Index() != -1 {
+			t.Errorf("Resolve(%v) = %s, %q; want Unknown, \"\"", empty, v, layer)
+		}
+	}
+}
+
+// TestChainMatchesCoalesce asserts that a chain always resolves to the same
+// value as Coalesce over its layer values.
+func TestChainMatchesCoalesce(t *testing.T) {
+	for _, a := range canonical {
+		for _, b := range canonical {
+			c := Chain{}.Add("a", a).Add("b", b)
+			if got, want := c.Value(), Coalesce(a, b); got != want {
+				t.Errorf("Chain(%s,%s) = %s, Coalesce = %s", a, b, got, want)
+			}
+		}
+	}
+}
+
+// TestChainAddShared checks that chains extended from a common base do not
+// overwrite each other's layers.
+func TestChainAddShared(t *testing.T) {
+	base := Chain{}.Add("user", Unknown).Add("team", Unknown).Add("org", Unknown)
+	a := base.Add("globalA", True)
+	b := base.Add("globalB", False)
+
+	if v, layer := a.Resolve(); v != True || layer != "globalA" {
+		t.Errorf("a.Resolve = %s, %q; want True, \"globalA\"", v, layer)
+	}
+	if v, layer := b.Resolve(); v != False || layer != "globalB" {
+		t.Errorf("b.Resolve = %s, %q; want False, \"globalB\"", v, layer)
+	}
+	if len(base) != 3 {
+		t.Errorf("base has %d layers, want 3", len(base))
+	}
+}
@@ -0,0 +1,295 @@
+// Package config populates trit.Trit fields of a struct from layered sources
+// such as environment variables, decoded configuration files and flags.
+//
+// Fields opt in with a struct tag:
+//
+//	type Features struct {
+//		Beta   trit.Trit `trit:"env=APP_BETA,key=beta,default=false"`
+//		Tracer trit.Trit `trit:"env=APP_TRACER"`
+//	}
+//
+// The tag options are:
+//   - env=NAME      the environment variable read by the Env source
+//   - key=NAME      the key read by the Map and Flags sources
+//   - default=VALUE the value used when no source decides the field
+//
+// Every raw value is parsed with trit.ParseTrit.
+//
+// # Layering
+//
+// Load merges the sources with the semantics of Trit.Default: a source only
+// fills a field that is still Unknown. The value already present in the
+// struct therefore wins, then the sources in the order they are passed, and
+// the tag default comes last. A source that explicitly yields Unknown (for
+// example APP_BETA=maybe) does not decide the field and lets the next layer
+// speak.
+//
+// Errors do not stop the load: every invalid value, from every source, is
+// reported in a single error built with errors.Join, so a user can fix all
+// bad variables at once.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/goloop/trit/v2"
+)
+
+// ErrInvalidTarget is returned when the destination of Load is not a non-nil
+// pointer to a struct.
+var ErrInvalidTarget = errors.New("config: target must be a non-nil pointer to a struct")
+
+// ErrInvalidTag is returned (wrapped) when a trit struct tag is malformed.
+var ErrInvalidTag = errors.New("config: invalid trit tag")
+
+// tritType is the reflected type of trit.Trit.
+var tritType = reflect.TypeFor[trit.Trit]()
+
+// Field describes a tagged trit.Trit field. It is what a Source receives to
+// decide which raw value, if any, belongs to the field.
+type Field struct {
+	// Path is the Go path of the field, e.g. "Features.Beta".
+	Path string
+
+	// Env is the value of the env= tag option.
+	Env string
+
+	// Key is the value of the key= tag option.
+	Key string
+
+	// Default is the raw value of the default= tag option.
+	Default string
+}
+
+// Source is one configuration layer.
+type Source interface {
+	// Lookup returns the raw value for f, a human-readable location of that
+	// value used in error messages (such as "$APP_BETA"), and whether the
+	// source defines the field at all.
+	Lookup(f Field) (value, location string, ok bool)
+}
+
+// SourceFunc adapts an ordinary function to the Source interface.
+type SourceFunc func(f Field) (value, location string, ok bool)
+
+// Lookup calls fn(f).
+func (fn SourceFunc) Lookup(f Field) (string, string, bool) {
+	return fn(f)
+}
+
+// ParseError records a raw value that could not be parsed as a Trit.
+type ParseError struct {
+	// Path is the Go path of the field.
+	Path string
+
+	// Location names where the value came from, e.g. "$APP_BETA".
+	Location string
+
+	// Value is the offending raw value.
+	Value string
+
+	// Err is the underlying error; it wraps trit.ErrInvalidTrit.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("config: %s (%s): %v", e.Path, e.Location, e.Err)
+}
+
+// Unwrap returns the underlying parse error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Env returns a Source that reads the variable named by the env= option.
+// Fields without an env= option are not looked up.
+func Env() Source {
+	return SourceFunc(func(f Field) (string, string, bool) {
+		if f.Env == "" {
+			return "", "", false
+		}
+
+		v, ok := os.LookupEnv(f.Env)
+		return v, "$" + f.Env, ok
+	})
+}
+
+// Map returns a Source that reads the entry named by the key= option from m,
+// typically a configuration file decoded into a map. The name is used in
+// error locations, e.g. "config.yaml:beta".
+func Map(name string, m map[string]string) Source {
+	return SourceFunc(func(f Field) (string, string, bool) {
+		if f.Key == "" {
+			return "", "", false
+		}
+
+		v, ok := m[f.Key]
+		return v, name + ":" + f.Key, ok
+	})
+}
+
+// Flags returns a Source that reads the flag named by the key= option from
+// fs. Only flags that were actually set on the command line are considered,
+// so a flag's default never shadows a lower layer. Call it after fs.Parse.
+func Flags(fs *flag.FlagSet) Source {
+	return SourceFunc(func(f Field) (string, string, bool) {
+		if f.Key == "" {
+			return "", "", false
+		}
+
+		var (
+			v  string
+			ok bool
+		)
+		fs.Visit(func(fl *flag.Flag) {
+			if fl.Name == f.Key {
+				v, ok = fl.Value.String(), true
+			}
+		})
+		return v, "-" + f.Key, ok
+	})
+}
+
+// FromEnv populates the tagged fields of dst from the environment. It is
+// shorthand for Load(dst, Env()).
+//
+// Example usage:
+//
+//	var cfg Features
+//	if err := config.FromEnv(&cfg); err != nil {
+//		log.Fatal(err) // lists every invalid variable
+//	}
+func FromEnv(dst any) error {
+	return Load(dst, Env())
+}
+
+// Load populates the tagged trit.Trit fields of the struct pointed to by dst
+// from the given sources, highest precedence first. See the package
+// documentation for the merge rules and the tag format.
+//
+// Nested and embedded structs are walked recursively, including embedded
+// structs of unexported types, whose exported fields are promoted as in
+// encoding/json. The returned error, if any, joins a *ParseError for every
+// invalid value and a wrapped ErrInvalidTag for every malformed tag; fields
+// are still populated from the valid layers.
+//
+// Example usage:
+//
+//	fs.Parse(os.Args[1:])
+//	err := config.Load(&cfg,
+//		config.Flags(fs),
+//		config.Env(),
+//		config.Map("config.yaml", fileValues),
+//	)
+func Load(dst any, sources ...Source) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	var errs []error
+	load(rv.Elem(), "", sources, &errs)
+	return errors.Join(errs...)
+}
+
+// load walks the struct value v, resolving each tagged Trit field.
+func load(v reflect.Value, prefix string, sources []Source, errs *[]error) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		tag, tagged := sf.Tag.Lookup("trit")
+		if tag == "-" {
+			continue
+		}
+
+		path := prefix + sf.Name
+		fv := v.Field(i)
+		switch {
+		case sf.Type == tritType:
+			if !tagged {
+				continue
+			}
+
+			f, err := parseTag(path, tag)
+			if err != nil {
+				*errs = append(*errs, err)
+				continue
+			}
+			fv.Set(reflect.ValueOf(resolve(fv.Interface().(trit.Trit), f, sources, errs)))
+		case sf.Type.Kind() == reflect.Struct:
+			load(fv, path+".", sources, errs)
+		}
+	}
+}
+
+// resolve merges the layers for a single field. Every source is consulted,
+// even after the value is decided, so that all invalid values are reported.
+func resolve(cur trit.Trit, f Field, sources []Source, errs *[]error) trit.Trit {
+	for _, src := range sources {
+		raw, loc, ok := src.Lookup(f)
+		if !ok {
+			continue
+		}
+
+		v, err := trit.ParseTrit(raw)
+		if err != nil {
+			*errs = append(*errs, &ParseError{
+				Path: f.Path, Location: loc, Value: raw, Err: err,
+			})
+			continue
+		}
+		cur.Default(v)
+	}
+
+	if f.Default != "" {
+		v, err := trit.ParseTrit(f.Default)
+		if err != nil {
+			*errs = append(*errs, &ParseError{
+				Path: f.Path, Location: "default", Value: f.Default, Err: err,
+			})
+		} else {
+			cur.Default(v)
+		}
+	}
+
+	return cur.Val()
+}
+
+// parseTag splits a trit struct tag into a Field.
+func parseTag(path, tag string) (Field, error) {
+	f := Field{Path: path}
+	for opt := range strings.SplitSeq(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			return f, fmt.Errorf("%w: %s: option %q", ErrInvalidTag, path, opt)
+		}
+
+		switch strings.TrimSpace(name) {
+		case "env":
+			f.Env = strings.TrimSpace(value)
+		case "key":
+			f.Key = strings.TrimSpace(value)
+		case "default":
+			f.Default = strings.TrimSpace(value)
+		default:
+			return f, fmt.Errorf("%w: %s: option %q", ErrInvalidTag, path, opt)
+		}
+	}
+
+	return f, nil
+}
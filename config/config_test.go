@@ -0,0 +1,165 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/goloop/trit/v2"
+)
+
+type nested struct {
+	Deep trit.Trit `trit:"env=TRIT_CFG_DEEP"`
+}
+
+type settings struct {
+	Alpha   trit.Trit `trit:"env=TRIT_CFG_ALPHA,key=alpha,default=false"`
+	Beta    trit.Trit `trit:"env=TRIT_CFG_BETA,default=unknown"`
+	Gamma   trit.Trit `trit:"key=gamma,default=true"`
+	Skipped trit.Trit `trit:"-"`
+	Plain   trit.Trit
+	Group   nested
+	nested
+}
+
+// TestFromEnv checks tag parsing, defaults, nested and embedded structs.
+func TestFromEnv(t *testing.T) {
+	t.Setenv("TRIT_CFG_ALPHA", "yes")
+	t.Setenv("TRIT_CFG_DEEP", "off")
+
+	var s settings
+	if err := FromEnv(&s); err != nil {
+		t.Fatalf("FromEnv: %v", err)
+	}
+
+	want := settings{
+		Alpha: trit.True,
+		Beta:  trit.Unknown,
+		Gamma: trit.True,
+		Group: nested{Deep: trit.False},
+	}
+	want.nested.Deep = trit.False // promoted from the unexported embedded type
+	if s != want {
+		t.Errorf("FromEnv = %+v, want %+v", s, want)
+	}
+}
+
+// TestLoadLayers checks the Default-like precedence: preset value, then the
+// sources in order, then the tag default; Unknown never overrides.
+func TestLoadLayers(t *testing.T) {
+	t.Setenv("TRIT_CFG_ALPHA", "false")
+	t.Setenv("TRIT_CFG_BETA", "maybe") // explicit Unknown defers downwards
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("alpha", "", "")
+	fs.String("gamma", "no", "") // default only, never set
+	if err := fs.Parse([]string{"-alpha=true"}); err != nil {
+		t.Fatal(err)
+	}
+
+	file := Map("file", map[string]string{"gamma": "unknown", "alpha": "false"})
+
+	s := settings{Plain: trit.True}
+	if err := Load(&s, Flags(fs), Env(), file); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if s.Alpha != trit.True {
+		t.Errorf("Alpha = %s, want True from flags", s.Alpha)
+	}
+	if s.Beta != trit.Unknown {
+		t.Errorf("Beta = %s, want Unknown", s.Beta)
+	}
+	if s.Gamma != trit.True {
+		t.Errorf("Gamma = %s, want True from the tag default", s.Gamma)
+	}
+	if s.Plain != trit.True {
+		t.Errorf("untagged field must be left alone, got %s", s.Plain)
+	}
+
+	// A value already present in the struct wins over every layer.
+	s = settings{Alpha: trit.False}
+	if err := Load(&s, Flags(fs)); err != nil {
+		t.Fatal(err)
+	}
+	if s.Alpha != trit.False {
+		t.Errorf("preset Alpha = %s, want False", s.Alpha)
+	}
+}
+
+// TestLoadErrors checks that every bad value is reported, from every layer,
+// and that valid layers are still applied.
+func TestLoadErrors(t *testing.T) {
+	t.Setenv("TRIT_CFG_ALPHA", "sometimes")
+	t.Setenv("TRIT_CFG_BETA", "yes")
+	t.Setenv("TRIT_CFG_DEEP", "perhaps")
+
+	var s settings
+	err := Load(&s, Env(), Map("file", map[string]string{"gamma": "2"}))
+	if err == nil {
+		t.Fatal("Load must fail")
+	}
+	if !errors.Is(err, trit.ErrInvalidTrit) {
+		t.Errorf("error must wrap ErrInvalidTrit: %v", err)
+	}
+
+	for _, want := range []string{"$TRIT_CFG_ALPHA", "$TRIT_CFG_DEEP", "file:gamma"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Path != "Alpha" || pe.Value != "sometimes" {
+		t.Errorf("errors.As = %+v", pe)
+	}
+
+	if s.Beta != trit.True || s.Alpha != trit.False {
+		t.Errorf("valid layers must still apply: Alpha=%s Beta=%s", s.Alpha, s.Beta)
+	}
+}
+
+// TestLoadInvalid checks the target validation and malformed tags.
+func TestLoadInvalid(t *testing.T) {
+	var s settings
+	for _, dst := range []any{nil, s, (*settings)(nil), new(int)} {
+		if err := Load(dst); !errors.Is(err, ErrInvalidTarget) {
+			t.Errorf("Load(%T) err = %v, want ErrInvalidTarget", dst, err)
+		}
+	}
+
+	var bad struct {
+		A trit.Trit `trit:"env=X,colour=red"`
+		B trit.Trit `trit:"env"`
+		C trit.Trit `trit:"default=sometimes"`
+	}
+	err := Load(&bad)
+	if !errors.Is(err, ErrInvalidTag) || !errors.Is(err, trit.ErrInvalidTrit) {
+		t.Errorf("Load(bad tags) err = %v", err)
+	}
+	if n := strings.Count(err.Error(), "\n") + 1; n != 3 {
+		t.Errorf("want 3 errors, got %d:\n%v", n, err)
+	}
+}
+
+// TestFlagsIntegration checks the Flags source with trit's own flag values.
+func TestFlagsIntegration(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var alpha, gamma trit.Trit
+	fs.Var(trit.NewFlagValue(&alpha, trit.Unknown), "alpha", "")
+	fs.Var(trit.NewFlagValue(&gamma, trit.Unknown), "gamma", "")
+	if err := fs.Parse([]string{"-alpha", "-gamma=false"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var s settings
+	if err := Load(&s, Flags(fs)); err != nil {
+		t.Fatal(err)
+	}
+	if s.Alpha != trit.True || s.Gamma != trit.False {
+		t.Errorf("Alpha=%s Gamma=%s, want True False", s.Alpha, s.Gamma)
+	}
+}
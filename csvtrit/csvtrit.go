@@ -0,0 +1,314 @@
+// Package csvtrit reads and writes tri-state columns of CSV files, such as
+// the yes/no/blank columns of exported spreadsheets, on top of
+// encoding/csv.
+//
+// A Decoder reads the named columns of a file with a header row into
+// trit.Slice values. It does not stop at the first bad cell: every cell that
+// its Vocabulary cannot read is reported, with its position, in an
+// ErrorList, and decodes as Unknown. An Encoder writes columns back using
+// the first word of each state in its Vocabulary.
+//
+// Example usage:
+//
+//	d := csvtrit.NewDecoder(csv.NewReader(f))
+//	d.Vocabulary = csvtrit.YesNo
+//	cols, err := d.Decode("subscribed", "verified")
+//	var cells csvtrit.ErrorList
+//	if errors.As(err, &cells) {
+//		for _, c := range cells {
+//			log.Printf("line %d, column %d: %v", c.Line, c.Column, c.Err)
+//		}
+//	} else if err != nil {
+//		return err
+//	}
+package csvtrit
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goloop/trit/v2"
+)
+
+// ErrMissingColumn is returned by Decoder.Decode when a requested column is
+// not in the header.
+var ErrMissingColumn = errors.New("csvtrit: missing column")
+
+// ErrLength is returned by Encoder.Encode when the columns differ in
+// length.
+var ErrLength = errors.New("csvtrit: columns differ in length")
+
+// Vocabulary lists the cell texts of each state. Matching ignores case and
+// surrounding spaces. The zero Vocabulary reads cells with trit.ParseTrit
+// and writes the state names of trit.Trit.String.
+type Vocabulary struct {
+	True, False, Unknown []string
+}
+
+// Common vocabularies. The first word of each state is the one written.
+var (
+	// YesNo reads yes/y, no/n and blank or n/a, and writes yes, no and an
+	// empty cell.
+	YesNo = Vocabulary{
+		True:    []string{"yes", "y"},
+		False:   []string{"no", "n"},
+		Unknown: []string{"", "n/a"},
+	}
+
+	// Letters reads and writes t, f and u, as PostgreSQL prints booleans.
+	Letters = Vocabulary{
+		True:    []string{"t"},
+		False:   []string{"f"},
+		Unknown: []string{"u", ""},
+	}
+
+	// Digits reads and writes 1, -1 and 0, the signs of trit.Define.
+	Digits = Vocabulary{
+		True:    []string{"1"},
+		False:   []string{"-1"},
+		Unknown: []string{"0", ""},
+	}
+)
+
+// Parse returns the state that cell names in v, or an error wrapping
+// trit.ErrInvalidTrit if it names none.
+func (v Vocabulary) Parse(cell string) (trit.Trit, error) {
+	if v.isZero() {
+		return trit.ParseTrit(cell)
+	}
+
+	s := strings.TrimSpace(cell)
+	for _, c := range []struct {
+		words []string
+		value trit.Trit
+	}{{v.True, trit.True}, {v.False, trit.False}, {v.Unknown, trit.Unknown}} {
+		for _, w := range c.words {
+			if strings.EqualFold(s, strings.TrimSpace(w)) {
+				return c.value, nil
+			}
+		}
+	}
+
+	return trit.Unknown, fmt.Errorf("%w: %q", trit.ErrInvalidTrit, cell)
+}
+
+// Format returns the cell text of t in v: the first word of its state, or
+// the state name if v has no word for it.
+func (v Vocabulary) Format(t trit.Trit) string {
+	words := v.Unknown
+	switch t.Val() {
+	case trit.True:
+		words = v.True
+	case trit.False:
+		words = v.False
+	}
+
+	if len(words) == 0 {
+		return t.String()
+	}
+
+	return words[0]
+}
+
+func (v Vocabulary) isZero() bool {
+	return v.True == nil && v.False == nil && v.Unknown == nil
+}
+
+// Column is a named tri-state column.
+type Column struct {
+	Name   string
+	Values trit.Slice
+}
+
+// CellError describes a cell that could not be decoded.
+type CellError struct {
+	Line   int    // line of the cell in the input, from 1
+	Column int    // field number of the cell in its record, from 1
+	Name   string // column name from the header
+	Value  string // the cell text
+	Err    error  // the parse error, wrapping trit.ErrInvalidTrit
+}
+
+// Error implements the error interface.
+func (e *CellError) Error() string {
+	return fmt.Sprintf("csvtrit: line %d, column %d (%s): %v",
+		e.Line, e.Column, e.Name, e.Err)
+}
+
+// Unwrap returns the parse error, so errors.Is(err, trit.ErrInvalidTrit)
+// holds.
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorList is the error of a Decode that met invalid cells, in input
+// order.
+type ErrorList []*CellError
+
+// Error implements the error interface: it describes the first cell error
+// and counts the rest.
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "csvtrit: no errors"
+	case 1:
+		return l[0].Error()
+	}
+
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// Unwrap returns the cell errors, so errors.Is and errors.As see each of
+// them.
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, e := range l {
+		errs[i] = e
+	}
+
+	return errs
+}
+
+// Decoder reads tri-state columns from CSV input with a header row.
+type Decoder struct {
+	r *csv.Reader
+
+	// Vocabulary reads the cells; the zero value uses trit.ParseTrit.
+	Vocabulary Vocabulary
+}
+
+// NewDecoder returns a Decoder reading from r. Configure r (separator,
+// comments, field counts) before the first Decode.
+func NewDecoder(r *csv.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Decode reads the header and all remaining records, and returns the named
+// columns in the order given; with no names it returns every column. Cells
+// the Vocabulary cannot read decode as Unknown and are reported together in
+// an ErrorList, alongside the decoded columns. A missing column
+// (ErrMissingColumn) or malformed CSV stops decoding with no columns.
+func (d *Decoder) Decode(names ...string) ([]Column, error) {
+	header, err := d.r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("csvtrit: no header: %w", io.ErrUnexpectedEOF)
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Spreadsheets exporting "CSV UTF-8" start the file with a byte order
+	// mark, which would otherwise stick to the first column name; padding
+	// around the names is dropped as well.
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	for i, h := range header {
+		header[i] = strings.TrimSpace(h)
+	}
+
+	if len(names) == 0 {
+		names = header
+	}
+
+	index := make([]int, len(names))
+	for i, name := range names {
+		index[i] = -1
+		for j, h := range header {
+			if h == strings.TrimSpace(name) {
+				index[i] = j
+				break
+			}
+		}
+		if index[i] < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrMissingColumn, name)
+		}
+	}
+
+	cols := make([]Column, len(names))
+	for i, name := range names {
+		cols[i] = Column{Name: name, Values: trit.Slice{}}
+	}
+
+	var errs ErrorList
+	for {
+		record, err := d.r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		for i, j := range index {
+			var cell string
+			if j < len(record) {
+				cell = record[j]
+			}
+
+			v, err := d.Vocabulary.Parse(cell)
+			if err != nil {
+				line, _ := d.r.FieldPos(min(j, len(record)-1))
+				errs = append(errs, &CellError{
+					Line: line, Column: j + 1, Name: names[i], Value: cell, Err: err,
+				})
+			}
+			cols[i].Values = append(cols[i].Values, v)
+		}
+	}
+
+	if len(errs) > 0 {
+		return cols, errs
+	}
+
+	return cols, nil
+}
+
+// Encoder writes tri-state columns as CSV with a header row.
+type Encoder struct {
+	w *csv.Writer
+
+	// Vocabulary writes the cells: the first word of each state. The zero
+	// value writes the state names True, False and Unknown.
+	Vocabulary Vocabulary
+}
+
+// NewEncoder returns an Encoder writing to w.
+func NewEncoder(w *csv.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes a header of the column names and one record per row, then
+// flushes. The columns must have the same length, or Encode returns
+// ErrLength without writing.
+func (e *Encoder) Encode(cols ...Column) error {
+	rows := 0
+	for i, c := range cols {
+		if i > 0 && len(c.Values) != rows {
+			return fmt.Errorf("%w: %q has %d values, %q has %d",
+				ErrLength, c.Name, len(c.Values), cols[0].Name, rows)
+		}
+		rows = len(c.Values)
+	}
+
+	record := make([]string, len(cols))
+	for i, c := range cols {
+		record[i] = c.Name
+	}
+	if err := e.w.Write(record); err != nil {
+		return err
+	}
+
+	for r := range rows {
+		for i, c := range cols {
+			record[i] = e.Vocabulary.Format(c.Values[r])
+		}
+		if err := e.w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,240 @@
+package csvtrit
+
+import (
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goloop/trit/v2"
+)
+
+const (
+	T = trit.True
+	F = trit.False
+	U = trit.Unknown
+)
+
+// decode decodes input with the vocabulary v.
+func decode(input string, v Vocabulary, names ...string) ([]Column, error) {
+	d := NewDecoder(csv.NewReader(strings.NewReader(input)))
+	d.Vocabulary = v
+	return d.Decode(names...)
+}
+
+// TestDecode checks column selection, ordering and the default vocabulary.
+func TestDecode(t *testing.T) {
+	input := "id,active,verified\n" +
+		"1,true,false\n" +
+		"2,,True\n" +
+		"3,false,unknown\n"
+
+	cols, err := decode(input, Vocabulary{}, "verified", "active")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if len(cols) != 2 || cols[0].Name != "verified" || cols[1].Name != "active" {
+		t.Fatalf("columns = %v", cols)
+	}
+	if want := (trit.Slice{F, T, U}); !slices.Equal(cols[0].Values, want) {
+		t.Errorf("verified = %v, want %v", cols[0].Values, want)
+	}
+	if want := (trit.Slice{T, U, F}); !slices.Equal(cols[1].Values, want) {
+		t.Errorf("active = %v, want %v", cols[1].Values, want)
+	}
+}
+
+// TestDecodeAll checks that no names selects every column.
+func TestDecodeAll(t *testing.T) {
+	cols, err := decode("a,b\nt,f\n", Letters)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if len(cols) != 2 || cols[0].Name != "a" || cols[1].Name != "b" {
+		t.Fatalf("columns = %v", cols)
+	}
+	if cols[0].Values[0] != T || cols[1].Values[0] != F {
+		t.Errorf("values = %v, %v", cols[0].Values, cols[1].Values)
+	}
+
+	// Padded header cells still name their own columns.
+	cols, err = decode("a, b \nyes,no\n", YesNo)
+	if err != nil {
+		t.Fatalf("Decode with padded header: %v", err)
+	}
+	if len(cols) != 2 || cols[1].Name != "b" || cols[1].Values[0] != F {
+		t.Errorf("padded header columns = %v", cols)
+	}
+}
+
+// TestDecodeBOM checks that a byte order mark before the header, as written
+// by spreadsheet exports, does not hide the first column.
+func TestDecodeBOM(t *testing.T) {
+	cols, err := decode("\ufeffsubscribed,verified\nyes,no\n", YesNo, "subscribed")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if cols[0].Values[0] != T {
+		t.Errorf("subscribed = %v, want [True]", cols[0].Values)
+	}
+
+	cols, err = decode("\ufeffsubscribed\nyes\n", YesNo)
+	if err != nil || cols[0].Name != "subscribed" {
+		t.Errorf("Decode() = %v, %v; want column \"subscribed\"", cols, err)
+	}
+}
+
+// TestDecodeErrors checks that every bad cell is reported with its position
+// and decodes as Unknown, while the good cells are kept.
+func TestDecodeErrors(t *testing.T) {
+	input := "name,subscribed,verified\n" +
+		"ann,yes,maybe\n" +
+		"bob,N,\n" +
+		"\"cid\nsmith\",perhaps,y\n"
+
+	cols, err := decode(input, YesNo, "subscribed", "verified")
+
+	var list ErrorList
+	if !errors.As(err, &list) {
+		t.Fatalf("Decode error = %v, want an ErrorList", err)
+	}
+	if !errors.Is(err, trit.ErrInvalidTrit) {
+		t.Errorf("errors.Is(err, ErrInvalidTrit) = false")
+	}
+
+	want := []CellError{
+		{Line: 2, Column: 3, Name: "verified", Value: "maybe"},
+		{Line: 5, Column: 2, Name: "subscribed", Value: "perhaps"},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("got %d cell errors, want %d: %v", len(list), len(want), err)
+	}
+	for i, w := range want {
+		got := list[i]
+		if got.Line != w.Line || got.Column != w.Column ||
+			got.Name != w.Name || got.Value != w.Value {
+			t.Errorf("error %d = %+v, want %+v", i, *got, w)
+		}
+	}
+
+	if w := (trit.Slice{T, F, U}); !slices.Equal(cols[0].Values, w) {
+		t.Errorf("subscribed = %v, want %v", cols[0].Values, w)
+	}
+	if w := (trit.Slice{U, U, T}); !slices.Equal(cols[1].Values, w) {
+		t.Errorf("verified = %v, want %v", cols[1].Values, w)
+	}
+
+	if msg := err.Error(); !strings.Contains(msg, "line 2, column 3") ||
+		!strings.Contains(msg, "1 more") {
+		t.Errorf("Error() = %q", msg)
+	}
+}
+
+// TestDecodeFatal checks the errors that stop decoding.
+func TestDecodeFatal(t *testing.T) {
+	if _, err := decode("a,b\n1,2\n", Digits, "c"); !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("missing column: err = %v, want ErrMissingColumn", err)
+	}
+
+	var perr *csv.ParseError
+	if _, err := decode("a,b\n1\n", Digits); !errors.As(err, &perr) {
+		t.Errorf("short record: err = %v, want a csv.ParseError", err)
+	}
+
+	if _, err := decode("", Digits); err == nil {
+		t.Errorf("empty input: err = nil")
+	}
+}
+
+// TestVocabulary checks matching and formatting.
+func TestVocabulary(t *testing.T) {
+	tests := []struct {
+		v    Vocabulary
+		cell string
+		want trit.Trit
+		ok   bool
+	}{
+		{YesNo, " YES ", T, true},
+		{YesNo, "n", F, true},
+		{YesNo, "N/A", U, true},
+		{YesNo, "true", U, false},
+		{Digits, "-1", F, true},
+		{Digits, "2", U, false},
+		{Vocabulary{}, "TRUE", T, true},
+		{Vocabulary{}, "nope", U, false},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.v.Parse(tt.cell)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("Parse(%q) = %v, %v; want %v, ok=%v",
+				tt.cell, got, err, tt.want, tt.ok)
+		}
+		if err != nil && !errors.Is(err, trit.ErrInvalidTrit) {
+			t.Errorf("Parse(%q) error %v does not wrap ErrInvalidTrit", tt.cell, err)
+		}
+	}
+
+	if got := YesNo.Format(U); got != "" {
+		t.Errorf("YesNo.Format(Unknown) = %q, want empty", got)
+	}
+	if got := (Vocabulary{True: []string{"on"}}).Format(F); got != "False" {
+		t.Errorf("Format without a word = %q, want False", got)
+	}
+}
+
+// TestEncode checks the output and that it decodes back.
+func TestEncode(t *testing.T) {
+	cols := []Column{
+		{Name: "a", Values: trit.Slice{T, F, U}},
+		{Name: "b", Values: trit.Slice{U, T, F}},
+	}
+
+	for _, v := range []Vocabulary{{}, YesNo, Letters, Digits} {
+		var b strings.Builder
+		e := NewEncoder(csv.NewWriter(&b))
+		e.Vocabulary = v
+		if err := e.Encode(cols...); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+
+		back, err := decode(b.String(), v)
+		if err != nil {
+			t.Fatalf("decode %q: %v", b.String(), err)
+		}
+		for i := range cols {
+			if !slices.Equal(back[i].Values, cols[i].Values) {
+				t.Errorf("round trip of %q: %v, want %v",
+					b.String(), back[i].Values, cols[i].Values)
+			}
+		}
+	}
+
+	var b strings.Builder
+	e := NewEncoder(csv.NewWriter(&b))
+	e.Vocabulary = Letters
+	if err := e.Encode(cols...); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if want := "a,b\nt,u\nf,t\nu,f\n"; b.String() != want {
+		t.Errorf("Encode = %q, want %q", b.String(), want)
+	}
+}
+
+// TestEncodeLength checks that uneven columns are rejected before writing.
+func TestEncodeLength(t *testing.T) {
+	var b strings.Builder
+	err := NewEncoder(csv.NewWriter(&b)).Encode(
+		Column{Name: "a", Values: trit.Slice{T}},
+		Column{Name: "b", Values: trit.Slice{T, F}},
+	)
+	if !errors.Is(err, ErrLength) {
+		t.Errorf("err = %v, want ErrLength", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("wrote %q", b.String())
+	}
+}
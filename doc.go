@@ -19,7 +19,16 @@
 //   - Extended operations (IMP, EQ, MIN, MAX)
 //   - Slice aggregates (All, Any, None, Known, Consensus, Majority) with
 //     iterator forms (AllSeq, AnySeq, NoneSeq, KnownSeq) over iter.Seq
-//   - Serialization: JSON, text, and database/sql (Unknown maps to NULL)
+//   - Stream combinators (Map, ZipWith, Window, Debounce, …) in the seq
+//     subpackage
+//   - SQL NULL semantics: nullable comparisons (Equal, InList, Between,
+//     Like, …) and an in-memory relational engine in the rel subpackage
+//   - Serialization: JSON, text, CBOR, and database/sql (Unknown maps to
+//     NULL); Protocol Buffers via the tritpb subpackage; CSV columns via
+//     the csvtrit subpackage
+//   - Layered resolution: Coalesce and Chain (which layer decided)
+//   - log/slog integration: LogValue, Attr and a handler middleware
+//   - Command-line flags (Flag, FlagVar) that keep "not given" as Unknown
 //   - Full set of comparison and testing methods
 //
 // # Quick Start
@@ -142,7 +151,8 @@
 // inputs. The package holds no shared mutable state, so distinct Trit values
 // may be used concurrently without synchronization. As with any value, a
 // single Trit that is mutated (via the pointer-receiver helpers) while being
-// read from another goroutine still requires external synchronization.
+// read from another goroutine still requires external synchronization; use
+// Atomic instead of a mutex-guarded Trit for lock-free shared state.
 //
 // # Performance Considerations
 //
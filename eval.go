@@ -0,0 +1,104 @@
+package trit
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// EvalAnd runs the predicates concurrently and returns their Kleene
+// conjunction, the same value as folding the results with Trit.And. Every
+// predicate gets its own goroutine; use EvalAndN to bound the number of
+// predicates running at a time, e.g. to protect a shared backend.
+//
+// EvalAnd returns as soon as the result is decided — at the first False —
+// and cancels the context passed to the predicates that are still running
+// or waiting to start. If ctx is done before the result is decided, the
+// predicates that have not reported count as Unknown, so a timeout can
+// never turn into a definite answer. With no predicates EvalAnd returns
+// True, the identity of AND.
+//
+// Predicates should map their own errors to Unknown and return promptly
+// once their context is cancelled; EvalAnd does not wait for stragglers.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Second)
+//	defer cancel()
+//	ready := trit.EvalAnd(ctx, checkDB, checkCache, checkQueue)
+func EvalAnd(ctx context.Context, fns ...func(context.Context) Trit) Trit {
+	return EvalAndN(ctx, 0, fns...)
+}
+
+// EvalAndN is EvalAnd with at most n predicates running concurrently. A
+// non-positive n means no bound. Predicates are started in argument order,
+// so with a small n the cheap or most decisive checks should come first.
+func EvalAndN(ctx context.Context, n int, fns ...func(context.Context) Trit) Trit {
+	return evalFold(ctx, n, Trit.And, True, False, fns)
+}
+
+// EvalOr runs the predicates concurrently and returns their Kleene
+// disjunction, the same value as folding the results with Trit.Or. It
+// returns at the first True and otherwise follows the rules of EvalAnd. With
+// no predicates EvalOr returns False, the identity of OR.
+func EvalOr(ctx context.Context, fns ...func(context.Context) Trit) Trit {
+	return EvalOrN(ctx, 0, fns...)
+}
+
+// EvalOrN is EvalOr with at most n predicates running concurrently. A
+// non-positive n means no bound.
+func EvalOrN(ctx context.Context, n int, fns ...func(context.Context) Trit) Trit {
+	return evalFold(ctx, n, Trit.Or, False, True, fns)
+}
+
+// evalFold runs fns on a pool of n workers and folds their results with op,
+// starting from identity and stopping as soon as the accumulator reaches the
+// absorbing element of op.
+func evalFold(
+	ctx context.Context,
+	n int,
+	op func(Trit, Trit) Trit,
+	identity, absorbing Trit,
+	fns []func(context.Context) Trit,
+) Trit {
+	if len(fns) == 0 {
+		return identity
+	}
+
+	if n <= 0 || n > len(fns) {
+		n = len(fns)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The buffer holds every possible result, so workers never block on a
+	// collector that has already returned.
+	results := make(chan Trit, len(fns))
+	var next atomic.Int64
+	for range n {
+		go func() {
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(fns) || ctx.Err() != nil {
+					return
+				}
+				results <- fns[i](ctx).Val()
+			}
+		}()
+	}
+
+	acc := identity
+	for range fns {
+		select {
+		case v := <-results:
+			if acc = op(acc, v); acc == absorbing {
+				return acc
+			}
+		case <-ctx.Done():
+			// The remaining predicates have not reported: they are Unknown.
+			return op(acc, Unknown)
+		}
+	}
+
+	return acc
+}
@@ -0,0 +1,141 @@
+package trit
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// constPred returns a predicate that reports v immediately.
+func constPred(v Trit) func(context.Context) Trit {
+	return func(context.Context) Trit { return v }
+}
+
+// blockPred returns a predicate that waits for its context and then reports
+// Unknown, counting how many times it was cancelled.
+func blockPred(cancelled *atomic.Int32) func(context.Context) Trit {
+	return func(ctx context.Context) Trit {
+		<-ctx.Done()
+		cancelled.Add(1)
+		return Unknown
+	}
+}
+
+// TestEvalMatchesSerialFold checks exhaustively, over every input of up to
+// four predicates and several pool sizes, that the concurrent result equals
+// the serial Kleene fold.
+func TestEvalMatchesSerialFold(t *testing.T) {
+	var inputs [][]Trit
+	var gen func(prefix []Trit)
+	gen = func(prefix []Trit) {
+		inputs = append(inputs, append([]Trit(nil), prefix...))
+		if len(prefix) == 4 {
+			return
+		}
+		for _, v := range canonical {
+			gen(append(prefix, v))
+		}
+	}
+	gen(nil)
+
+	for _, in := range inputs {
+		fns := make([]func(context.Context) Trit, len(in))
+		wantAnd, wantOr := True, False
+		for i, v := range in {
+			fns[i] = constPred(v)
+			wantAnd, wantOr = wantAnd.And(v), wantOr.Or(v)
+		}
+
+		for _, n := range []int{0, 1, 2, 8} {
+			if got := EvalAndN(t.Context(), n, fns...); got != wantAnd {
+				t.Errorf("EvalAndN(%d, %v) = %s, want %s", n, in, got, wantAnd)
+			}
+			if got := EvalOrN(t.Context(), n, fns...); got != wantOr {
+				t.Errorf("EvalOrN(%d, %v) = %s, want %s", n, in, got, wantOr)
+			}
+		}
+	}
+
+	if EvalAnd(t.Context()) != True || EvalOr(t.Context()) != False {
+		t.Errorf("empty EvalAnd/EvalOr must return the identities")
+	}
+}
+
+// TestEvalShortCircuit checks that a decisive result returns without waiting
+// for slow predicates and cancels them.
+func TestEvalShortCircuit(t *testing.T) {
+	var cancelled atomic.Int32
+	slow := blockPred(&cancelled)
+
+	if got := EvalAnd(t.Context(), slow, slow, constPred(False)); got != False {
+		t.Errorf("EvalAnd = %s, want False", got)
+	}
+	if got := EvalOrN(t.Context(), 4, slow, constPred(True), slow); got != True {
+		t.Errorf("EvalOrN = %s, want True", got)
+	}
+
+	deadline := time.After(time.Second)
+	for cancelled.Load() < 4 {
+		select {
+		case <-deadline:
+			t.Fatalf("only %d slow predicates were cancelled", cancelled.Load())
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// TestEvalTimeout checks that predicates still pending at the deadline count
+// as Unknown and never turn into a definite result.
+func TestEvalTimeout(t *testing.T) {
+	var cancelled atomic.Int32
+	slow := func(ctx context.Context) Trit {
+		select {
+		case <-ctx.Done():
+			cancelled.Add(1)
+			return Unknown
+		case <-time.After(time.Minute):
+			return False
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	if got := EvalAnd(ctx, constPred(True), slow); got != Unknown {
+		t.Errorf("EvalAnd after timeout = %s, want Unknown", got)
+	}
+
+	ctx, cancel = context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	if got := EvalOrN(ctx, 1, constPred(False), slow, constPred(True)); got != Unknown {
+		t.Errorf("EvalOrN after timeout = %s, want Unknown", got)
+	}
+}
+
+// TestEvalBound checks that no more than n predicates run at once.
+func TestEvalBound(t *testing.T) {
+	var running, peak atomic.Int32
+	pred := func(context.Context) Trit {
+		cur := running.Add(1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return True
+	}
+
+	fns := make([]func(context.Context) Trit, 16)
+	for i := range fns {
+		fns[i] = pred
+	}
+	if got := EvalAndN(t.Context(), 3, fns...); got != True {
+		t.Errorf("EvalAndN = %s, want True", got)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency %d exceeds bound 3", peak.Load())
+	}
+}
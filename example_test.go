@@ -74,3 +74,14 @@ func ExampleTrit_Compare() {
 	// 0
 	// 1
 }
+
+func ExampleChain() {
+	c := trit.Chain{
+		{Name: "user", Value: trit.Unknown},
+		{Name: "team", Value: trit.False},
+		{Name: "global", Value: trit.True},
+	}
+	v, layer := c.Resolve()
+	fmt.Printf("%s (decided by %s)\n", v, layer)
+	// Output: False (decided by team)
+}
@@ -0,0 +1,250 @@
+// Package expr builds three-valued rule expressions over named trit.Trit
+// variables, evaluates them in Go, and translates them to SQL boolean
+// expressions over nullable boolean columns that give the same result for
+// every row: SQL NULL wherever the Go result is Unknown.
+//
+// AND, OR and NOT are Kleene connectives in both worlds and translate
+// directly. The operators SQL lacks are spelled out so that NULLs behave as
+// in package trit:
+//
+//	Ma(a)     a IS NOT FALSE
+//	La(a)     a IS TRUE
+//	Ia(a)     a IS NULL
+//	Xor(a, b) a <> b
+//	Eq(a, b)  a = b
+//	Imp(a, b) CASE r(a) - r(b) WHEN 2 THEN FALSE WHEN 1 THEN NULL ELSE TRUE END
+//
+// where r(x) is CASE x WHEN TRUE THEN 2 WHEN FALSE THEN 0 ELSE 1 END, the
+// rank of x in the order False < Unknown < True. Imp follows trit.Imp, under
+// which Unknown implies Unknown is True, whereas the plain Kleene NOT a OR b
+// would give NULL. Each operand is written once, so nested implications
+// grow linearly.
+//
+// Example usage:
+//
+//	rule := expr.And(expr.Var("active"), expr.Imp(expr.Var("trial"), expr.Var("paid")))
+//	ok := rule.Eval(expr.Env{"active": trit.True, "trial": trit.False})
+//	where := expr.SQL(rule, expr.Postgres)
+package expr
+
+import (
+	"strings"
+
+	"github.com/goloop/trit/v2"
+)
+
+// Env binds variable names to values. A variable missing from the Env is
+// Unknown, as a NULL column would be.
+type Env map[string]trit.Trit
+
+// Expr is a three-valued expression. Build expressions with Var, Const and
+// the operator functions of this package.
+type Expr interface {
+	// Eval returns the value of the expression under env.
+	Eval(env Env) trit.Trit
+
+	// String returns the expression in function notation, such as
+	// and(a, imp(b, c)).
+	String() string
+
+	// sql writes the expression in dialect d.
+	sql(b *strings.Builder, d Dialect)
+}
+
+// Var returns the variable name, which SQL reads from the column of the
+// same name. A dotted name such as "u.active" is a qualified column.
+func Var(name string) Expr {
+	return varExpr(name)
+}
+
+// Const returns the constant v; Unknown is SQL NULL.
+func Const(v trit.Trit) Expr {
+	return constExpr(v.Val())
+}
+
+// Not returns the negation of x.
+func Not(x Expr) Expr {
+	return &unary{op: opNot, x: x}
+}
+
+// Ma returns trit.Ma of x: False only when x is False.
+func Ma(x Expr) Expr {
+	return &unary{op: opMa, x: x}
+}
+
+// La returns trit.La of x: True only when x is True.
+func La(x Expr) Expr {
+	return &unary{op: opLa, x: x}
+}
+
+// Ia returns trit.Ia of x: True only when x is Unknown.
+func Ia(x Expr) Expr {
+	return &unary{op: opIa, x: x}
+}
+
+// And returns the Kleene conjunction of xs. And() is True.
+func And(xs ...Expr) Expr {
+	return &nary{op: opAnd, args: xs}
+}
+
+// Or returns the Kleene disjunction of xs. Or() is False.
+func Or(xs ...Expr) Expr {
+	return &nary{op: opOr, args: xs}
+}
+
+// Xor returns the exclusive or of a and b.
+func Xor(a, b Expr) Expr {
+	return &binary{op: opXor, a: a, b: b}
+}
+
+// Eq returns the equivalence of a and b.
+func Eq(a, b Expr) Expr {
+	return &binary{op: opEq, a: a, b: b}
+}
+
+// Imp returns the implication a → b, as trit.Imp.
+func Imp(a, b Expr) Expr {
+	return &binary{op: opImp, a: a, b: b}
+}
+
+// Vars returns the names of the variables of e, each once, in order of
+// first appearance.
+func Vars(e Expr) []string {
+	var names []string
+	seen := make(map[string]bool)
+	walk(e, func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	})
+
+	return names
+}
+
+// walk calls fn for every variable of e, left to right.
+func walk(e Expr, fn func(string)) {
+	switch x := e.(type) {
+	case varExpr:
+		fn(string(x))
+	case *unary:
+		walk(x.x, fn)
+	case *binary:
+		walk(x.a, fn)
+		walk(x.b, fn)
+	case *nary:
+		for _, a := range x.args {
+			walk(a, fn)
+		}
+	}
+}
+
+type op int
+
+const (
+	opNot op = iota
+	opMa
+	opLa
+	opIa
+	opAnd
+	opOr
+	opXor
+	opEq
+	opImp
+)
+
+var opNames = [...]string{
+	opNot: "not", opMa: "ma", opLa: "la", opIa: "ia",
+	opAnd: "and", opOr: "or", opXor: "xor", opEq: "eq", opImp: "imp",
+}
+
+type varExpr string
+
+func (v varExpr) Eval(env Env) trit.Trit {
+	return env[string(v)].Val()
+}
+
+func (v varExpr) String() string {
+	return string(v)
+}
+
+type constExpr trit.Trit
+
+func (c constExpr) Eval(Env) trit.Trit {
+	return trit.Trit(c)
+}
+
+func (c constExpr) String() string {
+	return trit.Trit(c).String()
+}
+
+type unary struct {
+	op op
+	x  Expr
+}
+
+func (u *unary) Eval(env Env) trit.Trit {
+	v := u.x.Eval(env)
+	switch u.op {
+	case opMa:
+		return v.Ma()
+	case opLa:
+		return v.La()
+	case opIa:
+		return v.Ia()
+	}
+
+	return v.Not()
+}
+
+func (u *unary) String() string {
+	return opNames[u.op] + "(" + u.x.String() + ")"
+}
+
+type binary struct {
+	op   op
+	a, b Expr
+}
+
+func (x *binary) Eval(env Env) trit.Trit {
+	a, b := x.a.Eval(env), x.b.Eval(env)
+	switch x.op {
+	case opXor:
+		return a.Xor(b)
+	case opEq:
+		return a.Eq(b)
+	}
+
+	return a.Imp(b)
+}
+
+func (x *binary) String() string {
+	return opNames[x.op] + "(" + x.a.String() + ", " + x.b.String() + ")"
+}
+
+type nary struct {
+	op   op
+	args []Expr
+}
+
+func (x *nary) Eval(env Env) trit.Trit {
+	values := make([]trit.Trit, len(x.args))
+	for i, a := range x.args {
+		values[i] = a.Eval(env)
+	}
+
+	if x.op == opOr {
+		return trit.OrAll(values...)
+	}
+
+	return trit.AndAll(values...)
+}
+
+func (x *nary) String() string {
+	args := make([]string, len(x.args))
+	for i, a := range x.args {
+		args[i] = a.String()
+	}
+
+	return opNames[x.op] + "(" + strings.Join(args, ", ") + ")"
+}
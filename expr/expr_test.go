@@ -0,0 +1,438 @@
+package expr
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/goloop/trit/v2"
+)
+
+// sqlEval is a minimal SQL three-valued evaluator for the subset SQL
+// emits, standing in for a database. It implements the documented NULL
+// rules: NOT, AND and OR are Kleene; = and <> are NULL when either side is
+// NULL; IS [NOT] TRUE/FALSE/NULL/1/0 are never NULL; a simple CASE takes
+// the ELSE branch for a NULL operand. SQLite's 1 and 0 are read as TRUE and
+// FALSE, except as integer results of a CASE.
+type sqlEval struct {
+	toks []string
+	pos  int
+	row  map[string]trit.Trit
+}
+
+// evalSQL evaluates the SQL expression s for one row.
+func evalSQL(s string, row map[string]trit.Trit) (trit.Trit, error) {
+	e := &sqlEval{toks: tokenize(s), row: row}
+	v, err := e.or()
+	if err == nil && e.pos != len(e.toks) {
+		err = fmt.Errorf("trailing %q", e.toks[e.pos:])
+	}
+
+	return v, err
+}
+
+// tokenize splits s into parentheses, operators, words and quoted
+// identifiers (kept with their quotes).
+func tokenize(s string) []string {
+	var toks []string
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case c == ' ':
+			i++
+		case c == '(' || c == ')' || c == '=' || c == '.' || c == '-':
+			toks = append(toks, string(c))
+			i++
+		case strings.HasPrefix(s[i:], "<>"):
+			toks = append(toks, "<>")
+			i += 2
+		case c == '"' || c == '`':
+			j := i + 1
+			for j < len(s) && (s[j] != s[i] || (j+1 < len(s) && s[j+1] == s[i])) {
+				if s[j] == s[i] {
+					j++
+				}
+				j++
+			}
+			toks = append(toks, s[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j]))) {
+				j++
+			}
+			toks = append(toks, s[i:j])
+			i = j
+		}
+	}
+
+	return toks
+}
+
+func (e *sqlEval) peek() string {
+	if e.pos < len(e.toks) {
+		return e.toks[e.pos]
+	}
+	return ""
+}
+
+func (e *sqlEval) next() string {
+	t := e.peek()
+	e.pos++
+	return t
+}
+
+func (e *sqlEval) or() (trit.Trit, error) {
+	v, err := e.and()
+	for err == nil && e.peek() == "OR" {
+		e.next()
+		var w trit.Trit
+		w, err = e.and()
+		v = v.Or(w)
+	}
+	return v, err
+}
+
+func (e *sqlEval) and() (trit.Trit, error) {
+	v, err := e.not()
+	for err == nil && e.peek() == "AND" {
+		e.next()
+		var w trit.Trit
+		w, err = e.not()
+		v = v.And(w)
+	}
+	return v, err
+}
+
+func (e *sqlEval) not() (trit.Trit, error) {
+	if e.peek() == "NOT" {
+		e.next()
+		v, err := e.not()
+		return v.Not(), err
+	}
+	return e.cmp()
+}
+
+func (e *sqlEval) cmp() (trit.Trit, error) {
+	v, err := e.primary()
+	for err == nil {
+		switch e.peek() {
+		case "=", "<>":
+			op := e.next()
+			var w trit.Trit
+			if w, err = e.primary(); err != nil {
+				return v, err
+			}
+			v = v.Eq(w)
+			if op == "<>" {
+				v = v.Not()
+			}
+		case "IS":
+			e.next()
+			negate := e.peek() == "NOT"
+			if negate {
+				e.next()
+			}
+			var want trit.Trit
+			switch t := e.next(); t {
+			case "TRUE", "1":
+				want = trit.True
+			case "FALSE", "0":
+				want = trit.False
+			case "NULL":
+				want = trit.Unknown
+			default:
+				return v, fmt.Errorf("IS %s", t)
+			}
+			v = trit.Define(v == want)
+			if negate {
+				v = v.Not()
+			}
+		default:
+			return v, nil
+		}
+	}
+	return v, err
+}
+
+func (e *sqlEval) primary() (trit.Trit, error) {
+	switch t := e.next(); {
+	case t == "(":
+		v, err := e.or()
+		if err == nil && e.next() != ")" {
+			err = fmt.Errorf("missing )")
+		}
+		return v, err
+	case t == "CASE":
+		return e.boolCase()
+	case t == "TRUE" || t == "1":
+		return trit.True, nil
+	case t == "FALSE" || t == "0":
+		return trit.False, nil
+	case t == "NULL":
+		return trit.Unknown, nil
+	case strings.HasPrefix(t, `"`) || strings.HasPrefix(t, "`"):
+		name := unquote(t)
+		for e.peek() == "." {
+			e.next()
+			name += "." + unquote(e.next())
+		}
+		return e.row[name], nil
+	default:
+		return trit.Unknown, fmt.Errorf("unexpected %q", t)
+	}
+}
+
+// boolCase evaluates CASE n WHEN k THEN v ... ELSE v END, whose operand
+// is an integer expression and whose results are boolean.
+func (e *sqlEval) boolCase() (trit.Trit, error) {
+	n, err := e.intExpr()
+	result, matched := trit.Unknown, false
+	for err == nil && e.peek() == "WHEN" {
+		e.next()
+		k, convErr := strconv.Atoi(e.next())
+		if convErr != nil || e.next() != "THEN" {
+			return trit.Unknown, fmt.Errorf("bad WHEN in boolean CASE")
+		}
+		var v trit.Trit
+		if v, err = e.or(); !matched && n == k {
+			result, matched = v, true
+		}
+	}
+	if err == nil && e.next() == "ELSE" {
+		var v trit.Trit
+		if v, err = e.or(); !matched {
+			result = v
+		}
+	}
+	if err == nil && e.next() != "END" {
+		err = fmt.Errorf("missing END")
+	}
+
+	return result, err
+}
+
+// intExpr evaluates a difference of integer terms: numbers, parenthesized
+// integer expressions and CASE b WHEN TRUE THEN n ... ELSE n END over a
+// boolean operand.
+func (e *sqlEval) intExpr() (int, error) {
+	n, err := e.intTerm()
+	for err == nil && e.peek() == "-" {
+		e.next()
+		var m int
+		m, err = e.intTerm()
+		n -= m
+	}
+	return n, err
+}
+
+func (e *sqlEval) intTerm() (int, error) {
+	switch t := e.next(); t {
+	case "(":
+		n, err := e.intExpr()
+		if err == nil && e.next() != ")" {
+			err = fmt.Errorf("missing )")
+		}
+		return n, err
+	case "CASE":
+		b, err := e.or()
+		result, matched := 0, false
+		for err == nil && e.peek() == "WHEN" {
+			e.next()
+			var k trit.Trit
+			if k, err = e.primary(); err != nil {
+				return 0, err
+			}
+			n, convErr := e.thenInt()
+			if convErr != nil {
+				return 0, convErr
+			}
+			if !matched && !b.IsUnknown() && b == k {
+				result, matched = n, true
+			}
+		}
+		if err == nil && e.next() == "ELSE" {
+			n, convErr := strconv.Atoi(e.next())
+			if convErr != nil {
+				return 0, convErr
+			}
+			if !matched {
+				result = n
+			}
+		}
+		if err == nil && e.next() != "END" {
+			err = fmt.Errorf("missing END")
+		}
+		return result, err
+	default:
+		return strconv.Atoi(t)
+	}
+}
+
+// thenInt reads THEN n.
+func (e *sqlEval) thenInt() (int, error) {
+	if e.next() != "THEN" {
+		return 0, fmt.Errorf("missing THEN")
+	}
+	return strconv.Atoi(e.next())
+}
+
+func unquote(t string) string {
+	q := t[:1]
+	return strings.ReplaceAll(t[1:len(t)-1], q+q, q)
+}
+
+var vars = []string{"a", "b", "c"}
+
+// randomExpr returns a random expression of the given depth.
+func randomExpr(r *rand.Rand, depth int) Expr {
+	if depth == 0 || r.IntN(4) == 0 {
+		if r.IntN(5) == 0 {
+			return Const(trit.Trit(r.IntN(3) - 1))
+		}
+		return Var(vars[r.IntN(len(vars))])
+	}
+
+	sub := func() Expr { return randomExpr(r, depth-1) }
+	switch r.IntN(9) {
+	case 0:
+		return Not(sub())
+	case 1:
+		return Ma(sub())
+	case 2:
+		return La(sub())
+	case 3:
+		return Ia(sub())
+	case 4:
+		return And(sub(), sub(), sub())
+	case 5:
+		return Or(sub(), sub())
+	case 6:
+		return Xor(sub(), sub())
+	case 7:
+		return Eq(sub(), sub())
+	}
+	return Imp(sub(), sub())
+}
+
+// rows returns every assignment of the variables, NULL included.
+func rows() []map[string]trit.Trit {
+	out := []map[string]trit.Trit{{}}
+	for _, name := range vars {
+		var next []map[string]trit.Trit
+		for _, row := range out {
+			for _, v := range []trit.Trit{trit.False, trit.Unknown, trit.True} {
+				r := map[string]trit.Trit{name: v}
+				for k, x := range row {
+					r[k] = x
+				}
+				next = append(next, r)
+			}
+		}
+		out = next
+	}
+	return out
+}
+
+// TestSQLMatchesEval is the central property: for random expressions, every
+// dialect and every row, the SQL result equals the Go result.
+func TestSQLMatchesEval(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	all := rows()
+	for range 500 {
+		e := randomExpr(r, 4)
+		for _, d := range []Dialect{Postgres, SQLite, MySQL} {
+			s := SQL(e, d)
+			for _, row := range all {
+				got, err := evalSQL(s, row)
+				if err != nil {
+					t.Fatalf("%s: cannot evaluate %s: %v", d, s, err)
+				}
+				if want := e.Eval(Env(row)); got != want {
+					t.Fatalf("%s %v: SQL %s = %s, Go %s = %s",
+						d, row, s, got, e, want)
+				}
+			}
+		}
+	}
+}
+
+// TestImpUnknownUnknown pins the case where plain Kleene NOT a OR b would
+// differ from trit.Imp.
+func TestImpUnknownUnknown(t *testing.T) {
+	e := Imp(Var("a"), Var("b"))
+	s := SQL(e, Postgres)
+	got, err := evalSQL(s, map[string]trit.Trit{})
+	if err != nil || got != trit.True {
+		t.Errorf("%s with NULLs = %s (%v), want TRUE", s, got, err)
+	}
+}
+
+// TestImpWritesOperandsOnce checks that nested implications grow linearly:
+// every variable appears in the SQL exactly once.
+func TestImpWritesOperandsOnce(t *testing.T) {
+	left := Imp(Imp(Imp(Var("a"), Var("b")), Var("c")), Var("d"))
+	right := Imp(Var("a"), Imp(Var("b"), Imp(Var("c"), Var("d"))))
+	for _, e := range []Expr{left, right} {
+		s := SQL(e, Postgres)
+		for _, v := range []string{`"a"`, `"b"`, `"c"`, `"d"`} {
+			if n := strings.Count(s, v); n != 1 {
+				t.Errorf("%s: %s appears %d times in %s", e, v, n, s)
+			}
+		}
+	}
+}
+
+// TestSQLText pins the emitted text of each dialect.
+func TestSQLText(t *testing.T) {
+	rule := And(Var("active"), Imp(Var("trial"), Var("paid")))
+	want := `("active" AND (CASE (CASE "trial" WHEN TRUE THEN 2 WHEN FALSE THEN 0 ELSE 1 END)` +
+		` - (CASE "paid" WHEN TRUE THEN 2 WHEN FALSE THEN 0 ELSE 1 END)` +
+		` WHEN 2 THEN FALSE WHEN 1 THEN NULL ELSE TRUE END))`
+	if got := SQL(rule, Postgres); got != want {
+		t.Errorf("Postgres:\n got %s\nwant %s", got, want)
+	}
+
+	cases := []struct {
+		e    Expr
+		d    Dialect
+		want string
+	}{
+		{Ma(Var("verified")), SQLite, `("verified" IS NOT 0)`},
+		{La(Var("verified")), MySQL, "(`verified` IS TRUE)"},
+		{Ia(Var("u.ok")), Postgres, `("u"."ok" IS NULL)`},
+		{Var(`we"ird`), Postgres, `"we""ird"`},
+		{Var("we`ird"), MySQL, "`we``ird`"},
+		{Or(Const(trit.True), Const(trit.Unknown)), SQLite, "(1 OR NULL)"},
+		{And(), Postgres, "TRUE"},
+		{Or(), SQLite, "0"},
+		{Xor(Var("a"), Var("b")), MySQL, "(`a` <> `b`)"},
+		{Eq(Var("a"), Const(trit.False)), Postgres, `("a" = FALSE)`},
+	}
+	for _, c := range cases {
+		if got := SQL(c.e, c.d); got != c.want {
+			t.Errorf("%s %s = %s, want %s", c.d, c.e, got, c.want)
+		}
+	}
+}
+
+// TestEvalAndVars covers evaluation with missing variables, String and
+// Vars.
+func TestEvalAndVars(t *testing.T) {
+	e := Or(Not(Var("a")), Imp(Var("b"), Var("a")), Const(trit.False))
+	if got := e.Eval(Env{"a": trit.True}); got != trit.True {
+		t.Errorf("Eval = %s, want True", got)
+	}
+	if got := e.Eval(nil); got != trit.True { // Unknown → Unknown is True
+		t.Errorf("Eval(nil) = %s, want True", got)
+	}
+	if got, want := e.String(), "or(not(a), imp(b, a), False)"; got != want {
+		t.Errorf("String = %s, want %s", got, want)
+	}
+	if got := Vars(e); strings.Join(got, ",") != "a,b" {
+		t.Errorf("Vars = %v, want [a b]", got)
+	}
+}
@@ -0,0 +1,164 @@
+package expr
+
+import (
+	"strings"
+
+	"github.com/goloop/trit/v2"
+)
+
+// Dialect selects the SQL flavour SQL writes.
+type Dialect int
+
+const (
+	// Postgres writes PostgreSQL: "quoted" identifiers, TRUE and FALSE,
+	// IS TRUE and IS NOT FALSE.
+	Postgres Dialect = iota
+
+	// SQLite writes SQLite, whose booleans are the integers 1 and 0:
+	// "quoted" identifiers, 1 and 0, IS 1 and IS NOT 0. Columns must hold
+	// 1, 0 or NULL.
+	SQLite
+
+	// MySQL writes MySQL: `quoted` identifiers, TRUE and FALSE, IS TRUE
+	// and IS NOT FALSE.
+	MySQL
+)
+
+// String returns the name of the dialect.
+func (d Dialect) String() string {
+	switch d {
+	case SQLite:
+		return "SQLite"
+	case MySQL:
+		return "MySQL"
+	}
+
+	return "Postgres"
+}
+
+// SQL returns e as a boolean SQL expression in dialect d. Variables become
+// column references, and for every row the expression is TRUE, FALSE or
+// NULL exactly where e evaluates to True, False or Unknown with the row's
+// column values (NULL as Unknown). Every compound operand is parenthesized,
+// so the result can be embedded in any WHERE clause.
+//
+// Example usage:
+//
+//	e := expr.Ma(expr.Var("verified"))
+//	fmt.Println(expr.SQL(e, expr.SQLite)) // Output: ("verified" IS NOT 0)
+func SQL(e Expr, d Dialect) string {
+	var b strings.Builder
+	e.sql(&b, d)
+	return b.String()
+}
+
+func (v varExpr) sql(b *strings.Builder, d Dialect) {
+	quote := `"`
+	if d == MySQL {
+		quote = "`"
+	}
+
+	for i, part := range strings.Split(string(v), ".") {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(quote)
+		b.WriteString(strings.ReplaceAll(part, quote, quote+quote))
+		b.WriteString(quote)
+	}
+}
+
+func (c constExpr) sql(b *strings.Builder, d Dialect) {
+	b.WriteString(literal(trit.Trit(c), d))
+}
+
+// literal returns the SQL literal of v.
+func literal(v trit.Trit, d Dialect) string {
+	switch {
+	case v.IsUnknown():
+		return "NULL"
+	case d == SQLite && v.IsTrue():
+		return "1"
+	case d == SQLite:
+		return "0"
+	case v.IsTrue():
+		return "TRUE"
+	}
+
+	return "FALSE"
+}
+
+func (u *unary) sql(b *strings.Builder, d Dialect) {
+	b.WriteByte('(')
+	switch u.op {
+	case opNot:
+		b.WriteString("NOT ")
+		u.x.sql(b, d)
+	case opMa:
+		u.x.sql(b, d)
+		b.WriteString(" IS NOT " + literal(trit.False, d))
+	case opLa:
+		u.x.sql(b, d)
+		b.WriteString(" IS " + literal(trit.True, d))
+	case opIa:
+		u.x.sql(b, d)
+		b.WriteString(" IS NULL")
+	}
+	b.WriteByte(')')
+}
+
+func (x *binary) sql(b *strings.Builder, d Dialect) {
+	b.WriteByte('(')
+	switch x.op {
+	case opXor:
+		x.a.sql(b, d)
+		b.WriteString(" <> ")
+		x.b.sql(b, d)
+	case opEq:
+		x.a.sql(b, d)
+		b.WriteString(" = ")
+		x.b.sql(b, d)
+	case opImp:
+		b.WriteString("CASE ")
+		rank(b, x.a, d)
+		b.WriteString(" - ")
+		rank(b, x.b, d)
+		b.WriteString(" WHEN 2 THEN " + literal(trit.False, d) +
+			" WHEN 1 THEN NULL ELSE " + literal(trit.True, d) + " END")
+	}
+	b.WriteByte(')')
+}
+
+// rank writes the rank of e in the order False < Unknown < True as the
+// integer 0, 1 or 2, evaluating e once.
+func rank(b *strings.Builder, e Expr, d Dialect) {
+	b.WriteString("(CASE ")
+	e.sql(b, d)
+	b.WriteString(" WHEN " + literal(trit.True, d) + " THEN 2 WHEN " +
+		literal(trit.False, d) + " THEN 0 ELSE 1 END)")
+}
+
+func (x *nary) sql(b *strings.Builder, d Dialect) {
+	sep, empty := " AND ", trit.True
+	if x.op == opOr {
+		sep, empty = " OR ", trit.False
+	}
+
+	switch len(x.args) {
+	case 0:
+		b.WriteString(literal(empty, d))
+		return
+	case 1:
+		x.args[0].sql(b, d)
+		return
+	}
+
+	b.WriteByte('(')
+	for i, a := range x.args {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		a.sql(b, d)
+	}
+	b.WriteByte(')')
+}
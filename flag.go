@@ -0,0 +1,103 @@
+package trit
+
+import "flag"
+
+// FlagValue is a flag.Value that stores its state in a Trit. It behaves like
+// a boolean flag, so the three states of the Trit map onto the three ways a
+// user can (not) mention a flag on the command line:
+//   - absent               -> the default value (usually Unknown)
+//   - --feature            -> True
+//   - --feature=<value>    -> any value understood by ParseTrit
+//
+// This lets a program tell "the user didn't say" apart from "the user said
+// no", which a plain bool flag cannot.
+//
+// FlagValue also implements the Type method of the github.com/spf13/pflag
+// Value interface. pflag does not consult IsBoolFlag, so set NoOptDefVal to
+// "true" on the registered flag to allow the bare --feature form there:
+//
+//	fs.VarPF(trit.NewFlagValue(&v, trit.Unknown), "feature", "", "usage").
+//		NoOptDefVal = "true"
+type FlagValue Trit
+
+// NewFlagValue sets *p to value and returns a FlagValue backed by p, ready to
+// be registered with flag.FlagSet.Var or pflag.FlagSet.Var.
+//
+// Example usage:
+//
+//	var feature trit.Trit
+//	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+//	fs.Var(trit.NewFlagValue(&feature, trit.Unknown), "feature", "usage")
+func NewFlagValue(p *Trit, value Trit) *FlagValue {
+	*p = value.Val()
+	return (*FlagValue)(p)
+}
+
+// Set implements the flag.Value interface. It accepts any value understood
+// by ParseTrit and returns ErrInvalidTrit otherwise.
+func (f *FlagValue) Set(s string) error {
+	v, err := ParseTrit(s)
+	if err != nil {
+		return err
+	}
+
+	*f = FlagValue(v)
+	return nil
+}
+
+// String implements the flag.Value interface. The result is accepted by
+// ParseTrit, so it round-trips through Set.
+func (f *FlagValue) String() string {
+	if f == nil {
+		return Unknown.String()
+	}
+
+	return Trit(*f).String()
+}
+
+// Get implements the flag.Getter interface and returns the current Trit.
+// Like String, it reports Unknown for a nil receiver.
+func (f *FlagValue) Get() any {
+	if f == nil {
+		return Unknown
+	}
+
+	return Trit(*f).Val()
+}
+
+// IsBoolFlag marks the flag as boolean for the flag package, so a bare
+// --feature is accepted and sets the value to True.
+func (f *FlagValue) IsBoolFlag() bool {
+	return true
+}
+
+// Type implements the pflag.Value interface.
+func (f *FlagValue) Type() string {
+	return "trit"
+}
+
+// FlagVar defines a Trit flag with the specified name, default value, and
+// usage string on flag.CommandLine. The argument p points to a Trit variable
+// in which to store the value of the flag.
+//
+// See FlagValue for the accepted command-line forms.
+func FlagVar(p *Trit, name string, value Trit, usage string) {
+	flag.Var(NewFlagValue(p, value), name, usage)
+}
+
+// Flag defines a Trit flag with the specified name, default value, and usage
+// string on flag.CommandLine. The return value is the address of a Trit
+// variable that stores the value of the flag.
+//
+// Example usage:
+//
+//	feature := trit.Flag("feature", trit.Unknown, "enable the feature")
+//	flag.Parse()
+//	if feature.IsUnknown() {
+//		// The user did not pass -feature at all.
+//	}
+func Flag(name string, value Trit, usage string) *Trit {
+	p := new(Trit)
+	FlagVar(p, name, value, usage)
+	return p
+}
@@ -0,0 +1,140 @@
+package trit
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+// newTestFlagSet returns a silent FlagSet with a single "feature" flag bound
+// to the returned Trit.
+func newTestFlagSet(value Trit) (*flag.FlagSet, *Trit) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	p := new(Trit)
+	fs.Var(NewFlagValue(p, value), "feature", "usage")
+	return fs, p
+}
+
+// TestFlagForms checks the three ways a flag can appear (or not) on the
+// command line and that each lands on the documented state.
+func TestFlagForms(t *testing.T) {
+	cases := []struct {
+		args []string
+		def  Trit
+		want Trit
+	}{
+		{nil, Unknown, Unknown},
+		{nil, False, False},
+		{[]string{"-feature"}, Unknown, True},
+		{[]string{"--feature"}, False, True},
+		{[]string{"--feature=false"}, Unknown, False},
+		{[]string{"--feature=no"}, True, False},
+		{[]string{"--feature=true"}, Unknown, True},
+		{[]string{"--feature=unknown"}, True, Unknown},
+		{[]string{"--feature="}, True, Unknown},
+	}
+	for _, c := range cases {
+		fs, p := newTestFlagSet(c.def)
+		if err := fs.Parse(c.args); err != nil {
+			t.Errorf("Parse(%q) error: %v", c.args, err)
+			continue
+		}
+		if *p != c.want {
+			t.Errorf("Parse(%q) with default %s = %s, want %s",
+				c.args, c.def, *p, c.want)
+		}
+	}
+}
+
+// TestFlagInvalid checks that junk is rejected with ErrInvalidTrit and that
+// a bool flag does not swallow the following positional argument.
+func TestFlagInvalid(t *testing.T) {
+	var v Trit
+	if err := NewFlagValue(&v, Unknown).Set("sometimes"); !errors.Is(err, ErrInvalidTrit) {
+		t.Errorf("Set(sometimes) err = %v, want ErrInvalidTrit", err)
+	}
+
+	// The flag package reports the error without wrapping it.
+	fs, _ := newTestFlagSet(Unknown)
+	if err := fs.Parse([]string{"--feature=sometimes"}); err == nil {
+		t.Errorf("Parse(sometimes) must fail")
+	}
+
+	fs, p := newTestFlagSet(Unknown)
+	if err := fs.Parse([]string{"--feature", "false"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if *p != True || fs.Arg(0) != "false" {
+		t.Errorf("bare flag = %s, arg = %q; want True, \"false\"", *p, fs.Arg(0))
+	}
+}
+
+// TestFlagValueRoundTrip checks that String round-trips through Set for
+// every state, which flag.PrintDefaults and config reloaders rely on.
+func TestFlagValueRoundTrip(t *testing.T) {
+	for _, v := range canonical {
+		var p Trit
+		f := NewFlagValue(&p, v)
+		var back Trit
+		if err := NewFlagValue(&back, Unknown).Set(f.String()); err != nil {
+			t.Fatalf("Set(%q): %v", f.String(), err)
+		}
+		if back != v || f.Get() != v {
+			t.Errorf("round-trip %s -> %q -> %s (Get %v)", v, f.String(), back, f.Get())
+		}
+	}
+
+	var nilValue *FlagValue
+	if nilValue.String() != "Unknown" {
+		t.Errorf("nil FlagValue String() = %q", nilValue.String())
+	}
+	if got := nilValue.Get(); got != Unknown {
+		t.Errorf("nil FlagValue Get() = %v, want Unknown", got)
+	}
+	if nilValue.Type() != "trit" || !nilValue.IsBoolFlag() {
+		t.Errorf("Type/IsBoolFlag mismatch")
+	}
+}
+
+// TestFlagDefaults checks that the Unknown default is treated as the zero
+// value by PrintDefaults while a definite default is shown.
+func TestFlagDefaults(t *testing.T) {
+	var out strings.Builder
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+	var a, b Trit
+	fs.Var(NewFlagValue(&a, Unknown), "a", "unset by default")
+	fs.Var(NewFlagValue(&b, False), "b", "off by default")
+	fs.PrintDefaults()
+
+	if strings.Contains(out.String(), "default Unknown") {
+		t.Errorf("Unknown default must not be printed:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "(default False)") {
+		t.Errorf("False default must be printed:\n%s", out.String())
+	}
+}
+
+// TestFlagCommandLine covers the flag.CommandLine helpers.
+func TestFlagCommandLine(t *testing.T) {
+	p := Flag("trit-test-flag", False, "usage")
+	if *p != False {
+		t.Errorf("Flag default = %s, want False", *p)
+	}
+	if err := flag.CommandLine.Set("trit-test-flag", "yes"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if *p != True {
+		t.Errorf("Flag after Set = %s, want True", *p)
+	}
+
+	var v Trit
+	FlagVar(&v, "trit-test-flagvar", True, "usage")
+	if v != True || flag.Lookup("trit-test-flagvar") == nil {
+		t.Errorf("FlagVar did not register or set the default")
+	}
+}
@@ -3,6 +3,7 @@ package trit
 import (
 	"iter"
 	"math/rand/v2"
+	"slices"
 )
 
 // This line asserts at compile time that the type *Trit
@@ -190,6 +191,9 @@ func Define[T Logicable](v T) Trit {
 // It follows the vacuous-truth convention of universal quantification: with
 // no arguments the predicate holds trivially, so All() returns True.
 //
+// All treats Unknown as a failure; use AndAll for the Kleene conjunction,
+// which returns Unknown when the result depends on Unknown values.
+//
 // Example usage:
 //
 //	t := trit.All(trit.True, trit.True, trit.True)
@@ -451,30 +455,7 @@ func Random(up ...uint8) Trit {
 //	result := trit.Consensus(t1, t2, t3)
 //	// result will be Unknown, as not all trits are the same
 func Consensus[T Logicable](trits ...T) Trit {
-	if len(trits) == 0 {
-		return Unknown
-	}
-
-	countT := 0
-	countF := 0
-	for _, x := range trits {
-		switch logicToTrit(x) {
-		case True:
-			countT++
-		case False:
-			countF++
-		default:
-			return Unknown
-		}
-	}
-
-	if countT == len(trits) {
-		return True
-	} else if countF == len(trits) {
-		return False
-	}
-
-	return Unknown
+	return tallyOf(trits).Consensus()
 }
 
 // Majority returns True if more than half of the input trits are True, False
@@ -488,24 +469,7 @@ func Consensus[T Logicable](trits ...T) Trit {
 //	result := trit.Majority(t1, t2, t3, t4)
 //	// result will be True, as more than half of the trits are True
 func Majority[T Logicable](trits ...T) Trit {
-	countT := 0
-	countF := 0
-	for _, x := range trits {
-		switch logicToTrit(x) {
-		case True:
-			countT++
-		case False:
-			countF++
-		}
-	}
-
-	if countT > len(trits)/2 {
-		return True
-	} else if countF > len(trits)/2 {
-		return False
-	}
-
-	return Unknown
+	return tallyOf(trits).Majority()
 }
 
 // AllSeq is the iterator form of All. It returns True if every value produced
@@ -568,3 +532,213 @@ func KnownSeq[T Logicable](seq iter.Seq[T]) Trit {
 
 	return True
 }
+
+// Coalesce returns the first definite (True or False) value, or Unknown if
+// every value is Unknown. It is the n-ary form of Trit.Default and resolves a
+// precedence list in one call, highest precedence first:
+//
+//	trit.Coalesce(user, team, org, global)
+//
+// is equivalent to the nested user.Default(team.Default(...)) chain. With no
+// arguments Coalesce() returns Unknown. Use Chain when you also need to know
+// which layer decided the result.
+//
+// Example usage:
+//
+//	t := trit.Coalesce(trit.Unknown, trit.False, trit.True)
+//	fmt.Println(t.String()) // Output: False
+func Coalesce[T Logicable](ts ...T) Trit {
+	for _, v := range ts {
+		if t := logicToTrit(v); !t.IsUnknown() {
+			return t
+		}
+	}
+
+	return Unknown
+}
+
+// CoalesceSeq is the iterator form of Coalesce. It returns the first definite
+// value produced by seq and stops pulling at that point; if seq is exhausted
+// without one, it returns Unknown.
+func CoalesceSeq[T Logicable](seq iter.Seq[T]) Trit {
+	for v := range seq {
+		if t := logicToTrit(v); !t.IsUnknown() {
+			return t
+		}
+	}
+
+	return Unknown
+}
+
+// AndAll is the n-ary Kleene conjunction. It returns False if any value is
+// False, otherwise Unknown if any value is Unknown, and True if every value
+// is True. Unlike All, which treats Unknown as a failure, AndAll keeps "a
+// check failed" (False) apart from "a check has not reported yet" (Unknown).
+// With no arguments AndAll() returns True, the identity of conjunction.
+//
+// Example usage:
+//
+//	fmt.Println(trit.AndAll(trit.True, trit.Unknown))            // Unknown
+//	fmt.Println(trit.AndAll(trit.True, trit.Unknown, trit.False)) // False
+func AndAll[T Logicable](ts ...T) Trit {
+	return AndAllSeq(slices.Values(ts))
+}
+
+// AndAllSeq is the iterator form of AndAll. It stops pulling from seq at the
+// first False, since no later value can change the result.
+func AndAllSeq[T Logicable](seq iter.Seq[T]) Trit {
+	result := True
+	for v := range seq {
+		switch logicToTrit(v) {
+		case False:
+			return False
+		case Unknown:
+			result = Unknown
+		}
+	}
+
+	return result
+}
+
+// OrAll is the n-ary Kleene disjunction. It returns True if any value is
+// True, otherwise Unknown if any value is Unknown, and False if every value
+// is False. With no arguments OrAll() returns False, the identity of
+// disjunction.
+//
+// Example usage:
+//
+//	fmt.Println(trit.OrAll(trit.False, trit.Unknown)) // Unknown
+func OrAll[T Logicable](ts ...T) Trit {
+	return OrAllSeq(slices.Values(ts))
+}
+
+// OrAllSeq is the iterator form of OrAll. It stops pulling from seq at the
+// first True.
+func OrAllSeq[T Logicable](seq iter.Seq[T]) Trit {
+	result := False
+	for v := range seq {
+		switch logicToTrit(v) {
+		case True:
+			return True
+		case Unknown:
+			result = Unknown
+		}
+	}
+
+	return result
+}
+
+// XorAll is the n-ary exclusive or (parity). It returns True if an odd
+// number of values are True and none is Unknown, False for an even number,
+// and Unknown as soon as any value is Unknown, because that value alone can
+// flip the parity. With no arguments XorAll() returns False.
+//
+// Example usage:
+//
+//	fmt.Println(trit.XorAll(trit.True, trit.True, trit.True)) // True
+func XorAll[T Logicable](ts ...T) Trit {
+	return XorAllSeq(slices.Values(ts))
+}
+
+// XorAllSeq is the iterator form of XorAll. It stops pulling from seq at the
+// first Unknown.
+func XorAllSeq[T Logicable](seq iter.Seq[T]) Trit {
+	result := False
+	for v := range seq {
+		switch logicToTrit(v) {
+		case True:
+			result = result.Not()
+		case Unknown:
+			return Unknown
+		}
+	}
+
+	return result
+}
+
+// EqAll reports whether all values are equal. It returns False as soon as
+// both a True and a False have been seen, True if no resolution of the
+// Unknown values could make two values differ, and Unknown otherwise.
+//
+// This is stricter than folding Eq over neighbours: in {True, Unknown,
+// False} each neighbouring pair is Unknown, yet the values can never all be
+// equal, so EqAll returns False. A single value, even Unknown, is always
+// equal to itself, and EqAll() returns True.
+//
+// Example usage:
+//
+//	fmt.Println(trit.EqAll(trit.True, trit.True))                // True
+//	fmt.Println(trit.EqAll(trit.True, trit.Unknown))             // Unknown
+//	fmt.Println(trit.EqAll(trit.True, trit.Unknown, trit.False)) // False
+func EqAll[T Logicable](ts ...T) Trit {
+	return EqAllSeq(slices.Values(ts))
+}
+
+// EqAllSeq is the iterator form of EqAll. It stops pulling from seq once
+// both a True and a False have been seen.
+func EqAllSeq[T Logicable](seq iter.Seq[T]) Trit {
+	var n int
+	var seenT, seenF, seenU bool
+	for v := range seq {
+		n++
+		switch logicToTrit(v) {
+		case True:
+			seenT = true
+		case False:
+			seenF = true
+		default:
+			seenU = true
+		}
+
+		if seenT && seenF {
+			return False
+		}
+	}
+
+	if seenU && n > 1 {
+		return Unknown
+	}
+
+	return True
+}
+
+// ImpChain evaluates the implication chain ts[0] → ts[1] → … → ts[n-1],
+// that is the conjunction of every ts[i] → ts[i+1]. The chain holds exactly
+// when the values read as False…False followed by True…True, so ImpChain
+// returns False if some True precedes some False, True if no resolution of
+// the Unknown values can place a True before a False, and Unknown otherwise.
+// Chains of fewer than two values return True.
+//
+// The result is decided by the Unknown values alone, so it differs from
+// folding the Łukasiewicz Imp pairwise, where Unknown → Unknown is True: a
+// chain of two independent Unknowns may still resolve to True → False.
+//
+// Example usage:
+//
+//	fmt.Println(trit.ImpChain(trit.False, trit.Unknown, trit.True)) // True
+//	fmt.Println(trit.ImpChain(trit.Unknown, trit.False))            // Unknown
+//	fmt.Println(trit.ImpChain(trit.True, trit.Unknown, trit.False)) // False
+func ImpChain[T Logicable](ts ...T) Trit {
+	return ImpChainSeq(slices.Values(ts))
+}
+
+// ImpChainSeq is the iterator form of ImpChain. It stops pulling from seq
+// at the first False that follows a True.
+func ImpChainSeq[T Logicable](seq iter.Seq[T]) Trit {
+	result := True
+	var seenT, seenTU bool // a True, and a True or Unknown, seen so far
+	for v := range seq {
+		t := logicToTrit(v)
+		if t != True && seenTU {
+			if t == False && seenT {
+				return False
+			}
+			result = Unknown
+		}
+
+		seenT = seenT || t == True
+		seenTU = seenTU || t != False
+	}
+
+	return result
+}
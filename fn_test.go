@@ -2,6 +2,7 @@ package trit
 
 import (
 	"math"
+	"slices"
 	"testing"
 )
 
@@ -376,3 +377,108 @@ func TestTritterInterface(t *testing.T) {
 		t.Errorf("zero *Trit via Tritter misbehaves")
 	}
 }
+
+// TestCoalesce checks that Coalesce returns the first definite value and
+// agrees with nested Default calls, which it is meant to replace.
+func TestCoalesce(t *testing.T) {
+	cases := []struct {
+		in   []Trit
+		want Trit
+	}{
+		{nil, Unknown},
+		{[]Trit{Unknown}, Unknown},
+		{[]Trit{Unknown, Unknown, Unknown}, Unknown},
+		{[]Trit{True, False}, True},
+		{[]Trit{Unknown, False, True}, False},
+		{[]Trit{Unknown, Unknown, Trit(5)}, True}, // normalized
+	}
+	for _, c := range cases {
+		if got := Coalesce(c.in...); got != c.want {
+			t.Errorf("Coalesce(%v) = %s, want %s", c.in, got, c.want)
+		}
+	}
+
+	// Exhaustive parity with the nested Default chain it replaces.
+	for _, a := range canonical {
+		for _, b := range canonical {
+			for _, c := range canonical {
+				x, y, z := a, b, c
+				want := x.Default(y.Default(z))
+				if got := Coalesce(a, b, c); got != want {
+					t.Errorf("Coalesce(%s,%s,%s) = %s, want %s", a, b, c, got, want)
+				}
+			}
+		}
+	}
+
+	if Coalesce(0, 0, -3, 1) != False {
+		t.Errorf("Coalesce must accept any Logicable type")
+	}
+}
+
+// TestKleeneAggregatesDecided verifies exhaustively that the n-ary Kleene
+// aggregates return Unknown exactly when resolving the Unknown inputs could
+// change the outcome, and the common outcome otherwise.
+func TestKleeneAggregatesDecided(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(...Trit) Trit
+		pass func([]Trit) bool
+	}{
+		{"AndAll", AndAll[Trit], func(r []Trit) bool {
+			return !slices.Contains(r, False)
+		}},
+		{"OrAll", OrAll[Trit], func(r []Trit) bool {
+			return slices.Contains(r, True)
+		}},
+		{"XorAll", XorAll[Trit], func(r []Trit) bool {
+			return countTrue(r)%2 == 1
+		}},
+		{"EqAll", EqAll[Trit], func(r []Trit) bool {
+			return !slices.Contains(r, True) || !slices.Contains(r, False)
+		}},
+		{"ImpChain", ImpChain[Trit], func(r []Trit) bool {
+			for i := 1; i < len(r); i++ {
+				if Imp(r[i-1], r[i]) != True {
+					return false
+				}
+			}
+			return true
+		}},
+	}
+
+	for _, c := range cases {
+		for _, in := range allInputs(5) {
+			if got, want := c.fn(in...), decided(in, c.pass); got != want {
+				t.Errorf("%s(%v) = %s, want %s", c.name, in, got, want)
+			}
+		}
+	}
+}
+
+// TestKleeneAggregatesBinary pins the two-argument cases to the truth
+// tables of the corresponding binary operators where they agree.
+func TestKleeneAggregatesBinary(t *testing.T) {
+	for _, a := range canonical {
+		for _, b := range canonical {
+			if got, want := AndAll(a, b), And(a, b); got != want {
+				t.Errorf("AndAll(%s,%s) = %s, want %s", a, b, got, want)
+			}
+			if got, want := OrAll(a, b), Or(a, b); got != want {
+				t.Errorf("OrAll(%s,%s) = %s, want %s", a, b, got, want)
+			}
+			if got, want := XorAll(a, b), Xor(a, b); got != want {
+				t.Errorf("XorAll(%s,%s) = %s, want %s", a, b, got, want)
+			}
+		}
+	}
+
+	if AndAll(1, 0, 2) != Unknown || OrAll(-1, 0) != Unknown {
+		t.Errorf("Kleene aggregates must accept any Logicable type")
+	}
+	if AndAll[Trit]() != True || OrAll[Trit]() != False ||
+		XorAll[Trit]() != False || EqAll[Trit]() != True ||
+		ImpChain[Trit]() != True || EqAll(Unknown) != True {
+		t.Errorf("empty and single-value conventions broken")
+	}
+}
@@ -2,6 +2,7 @@ package trit
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -32,6 +33,32 @@ func FuzzUnmarshalJSON(f *testing.F) {
 	})
 }
 
+// FuzzUnmarshalCBOR feeds arbitrary bytes to the CBOR decoder. It must never
+// panic, and on success the value must be canonical and re-encode to one of
+// the three canonical items.
+func FuzzUnmarshalCBOR(f *testing.F) {
+	for _, b := range [][]byte{
+		{0xf4}, {0xf5}, {0xf6}, {0xf7}, {0x00}, {0x20}, {0x1b, 1, 2, 3, 4, 5, 6, 7, 8},
+		{0xf9, 0x7e, 0x00}, {0xfb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, {}, {0x18},
+	} {
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v Trit
+		if err := v.UnmarshalCBOR(data); err == nil {
+			if !isCanonical(v) {
+				t.Fatalf("UnmarshalCBOR(%x) produced non-canonical %d", data, int8(v))
+			}
+			out, _ := v.MarshalCBOR()
+			var back Trit
+			if err := back.UnmarshalCBOR(out); err != nil || back != v {
+				t.Fatalf("re-encode %s -> %x -> %s, %v", v, out, back, err)
+			}
+		}
+	})
+}
+
 // FuzzParseTrit ensures ParseTrit never panics and always yields a canonical
 // value; on error it must return Unknown (documented behaviour).
 func FuzzParseTrit(f *testing.F) {
@@ -93,3 +120,65 @@ func FuzzScan(f *testing.F) {
 		}
 	})
 }
+
+// FuzzScanPGArray asserts the PostgreSQL array text parser never panics and
+// that every array it accepts is written back in a form it reads
+// identically.
+func FuzzScanPGArray(f *testing.F) {
+	for _, s := range []string{"{}", "{t,f,NULL}", `{"t", f}`, "[0:1]={t,f}",
+		"{{t},{f}}", "{{},{}}", "{t,{f}}", `{"\"}`} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		var v Slice
+		if err := v.Scan(s); err != nil {
+			return
+		}
+
+		text, _ := v.Value()
+		var back Slice
+		if err := back.Scan(text); err != nil || !slices.Equal(back, v) {
+			t.Fatalf("%q -> %v -> %v -> %v (%v)", s, v, text, back, err)
+		}
+	})
+}
+
+// FuzzScanPGBinary asserts the PostgreSQL binary array parser never panics
+// or allocates beyond its input, and that every Matrix it accepts encodes
+// back to an array it reads identically.
+func FuzzScanPGBinary(f *testing.F) {
+	f.Add([]byte(nil))
+	for _, m := range []Matrix{{}, {{True, False}, {Unknown, True}}} {
+		buf, _ := m.AppendPGBinary(nil)
+		f.Add(buf)
+	}
+	for _, s := range []Slice{{}, {True, Unknown, False}} {
+		buf, _ := s.AppendPGBinary(nil)
+		f.Add(buf)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, values, err := parsePGBinary(data)
+		if err != nil {
+			return
+		}
+		if 4*len(values) > len(data) {
+			t.Fatalf("%d elements from %d bytes", len(values), len(data))
+		}
+
+		var m Matrix
+		if err := m.ScanPGBinary(data); err != nil {
+			return
+		}
+
+		buf, err := m.AppendPGBinary(nil)
+		if err != nil {
+			t.Fatalf("AppendPGBinary(%v): %v", m, err)
+		}
+		var back Matrix
+		if err := back.ScanPGBinary(buf); err != nil || !slices.EqualFunc(back, m, slices.Equal) {
+			t.Fatalf("%x -> %v -> %x -> %v (%v)", data, m, buf, back, err)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package trit
+
+import (
+	"context"
+	"sync"
+)
+
+// Latch is a one-shot Trit that starts Unknown and can be resolved exactly
+// once to True or False. Goroutines that need the decision block in Wait
+// (or on Done) until it arrives, e.g. a go/no-go signal from a leader.
+//
+// The zero value is an unresolved Latch ready to use. A Latch must not be
+// copied after first use.
+//
+// Example usage:
+//
+//	var goNoGo trit.Latch
+//	go func() { goNoGo.Resolve(leader.Decide()) }()
+//	v, err := goNoGo.Wait(ctx) // blocks until resolved or ctx is done
+type Latch struct {
+	v    Atomic
+	once sync.Once
+	done chan struct{}
+}
+
+// NewLatch returns a new unresolved Latch.
+func NewLatch() *Latch {
+	return new(Latch)
+}
+
+// init lazily creates the done channel, so the zero Latch is usable.
+func (l *Latch) init() {
+	l.once.Do(func() {
+		l.done = make(chan struct{})
+	})
+}
+
+// Resolve sets the latch to v and releases all waiters. Only the first call
+// with a definite value takes effect; it reports whether this call resolved
+// the latch. Resolving to Unknown is a no-op that returns false.
+func (l *Latch) Resolve(v Trit) bool {
+	if !l.v.ResolveOnce(v) {
+		return false
+	}
+
+	l.init()
+	close(l.done)
+	return true
+}
+
+// Peek returns the resolved value without blocking, or Unknown while the
+// latch is pending.
+func (l *Latch) Peek() Trit {
+	return l.v.Load()
+}
+
+// Done returns a channel that is closed when the latch is resolved.
+func (l *Latch) Done() <-chan struct{} {
+	l.init()
+	return l.done
+}
+
+// Wait blocks until the latch is resolved and returns its value. If ctx is
+// done first, Wait returns Unknown and ctx.Err(). A latch that is already
+// resolved is reported even if ctx is already done.
+func (l *Latch) Wait(ctx context.Context) (Trit, error) {
+	if v := l.Peek(); !v.IsUnknown() {
+		return v, nil
+	}
+
+	select {
+	case <-l.Done():
+		return l.Peek(), nil
+	case <-ctx.Done():
+		return Unknown, ctx.Err()
+	}
+}
@@ -0,0 +1,105 @@
+package trit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestLatchResolve checks the one-shot contract on a zero Latch.
+func TestLatchResolve(t *testing.T) {
+	var l Latch
+	if l.Peek() != Unknown {
+		t.Fatalf("pending Peek = %s, want Unknown", l.Peek())
+	}
+	select {
+	case <-l.Done():
+		t.Fatal("Done must not be closed while pending")
+	default:
+	}
+
+	if l.Resolve(Unknown) {
+		t.Errorf("Resolve(Unknown) must be a no-op")
+	}
+	if !l.Resolve(Trit(-2)) || l.Peek() != False {
+		t.Errorf("first Resolve must win, Peek = %s", l.Peek())
+	}
+	if l.Resolve(True) || l.Peek() != False {
+		t.Errorf("second Resolve must lose, Peek = %s", l.Peek())
+	}
+
+	select {
+	case <-l.Done():
+	default:
+		t.Fatal("Done must be closed once resolved")
+	}
+
+	// A resolved latch answers even with a cancelled context.
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if v, err := l.Wait(ctx); v != False || err != nil {
+		t.Errorf("Wait on resolved latch = %s, %v", v, err)
+	}
+}
+
+// TestLatchWaitCancel checks that a pending Wait honours its context.
+func TestLatchWaitCancel(t *testing.T) {
+	l := NewLatch()
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	v, err := l.Wait(ctx)
+	if v != Unknown || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait = %s, %v; want Unknown, DeadlineExceeded", v, err)
+	}
+}
+
+// TestLatchConcurrent releases many waiters with racing resolvers; run with
+// -race. Every waiter must observe the single winning value.
+func TestLatchConcurrent(t *testing.T) {
+	const n = 32
+
+	l := NewLatch()
+	var (
+		wg      sync.WaitGroup
+		results = make(chan Trit, n)
+	)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := l.Wait(t.Context())
+			if err != nil {
+				t.Errorf("Wait: %v", err)
+			}
+			results <- v
+		}()
+	}
+
+	wins := make(chan Trit, n)
+	for i := range n {
+		go func() {
+			v := True
+			if i%2 == 1 {
+				v = False
+			}
+			if l.Resolve(v) {
+				wins <- v
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+	winner := <-wins
+	for v := range results {
+		if v != winner {
+			t.Errorf("waiter saw %s, winner was %s", v, winner)
+		}
+	}
+	if len(wins) != 0 {
+		t.Errorf("more than one Resolve won")
+	}
+}
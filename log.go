@@ -0,0 +1,200 @@
+package trit
+
+import (
+	"context"
+	"log/slog"
+)
+
+// This line asserts at compile time that Trit implements slog.LogValuer.
+var _ slog.LogValuer = Trit(0)
+
+// LogFormat selects how a Trit is represented in structured logs.
+type LogFormat uint8
+
+const (
+	// LogDefault keeps the representation a handler gives a Trit through its
+	// marshalers: JSON handlers emit true, false or null (as MarshalJSON) and
+	// text handlers emit True, False or Unknown (as MarshalText).
+	LogDefault LogFormat = iota
+
+	// LogText logs the String form: "True", "False" or "Unknown", in every
+	// handler.
+	LogText
+
+	// LogBool logs a boolean, with Unknown as a nil value. JSON handlers
+	// then emit true, false or null, which lets log pipelines filter on a
+	// real boolean field.
+	LogBool
+
+	// LogInt logs the canonical integer: 1, 0 or -1.
+	LogInt
+)
+
+// Value returns the slog.Value of t in the format f.
+func (f LogFormat) Value(t Trit) slog.Value {
+	switch f {
+	case LogText:
+		return slog.StringValue(t.String())
+	case LogBool:
+		if t.IsUnknown() {
+			return slog.AnyValue(nil)
+		}
+		return slog.BoolValue(t.IsTrue())
+	case LogInt:
+		return slog.Int64Value(int64(t.Int()))
+	}
+
+	return slog.AnyValue(loggedTrit(t.Val()))
+}
+
+// loggedTrit is a Trit without the LogValue method, so handlers encode it
+// with its marshalers instead of resolving it again.
+type loggedTrit Trit
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t loggedTrit) MarshalJSON() ([]byte, error) {
+	return Trit(t).MarshalJSON()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t loggedTrit) MarshalText() ([]byte, error) {
+	return Trit(t).MarshalText()
+}
+
+// LogValue implements the slog.LogValuer interface in the LogDefault
+// format, so a Trit logs as it did before it was a LogValuer. Use
+// NewLogHandler to choose another format for a handler.
+func (t Trit) LogValue() slog.Value {
+	return LogDefault.Value(t)
+}
+
+// Attr returns a slog.Attr for t. The Trit is stored unresolved, so handlers
+// wrapped by NewLogHandler can still recognize it, and it is rendered with
+// LogValue when the record is written.
+//
+// Example usage:
+//
+//	slog.Info("rollout", trit.Attr("beta", cfg.Beta))
+func Attr(key string, t Trit) slog.Attr {
+	return slog.Any(key, t)
+}
+
+// LogHandlerOptions configures the handler returned by NewLogHandler.
+type LogHandlerOptions struct {
+	// Format is the representation of Trit attributes written by this
+	// handler. LogDefault leaves it to the wrapped handler.
+	Format LogFormat
+
+	// DropUnknown removes attributes whose value is an Unknown Trit.
+	DropUnknown bool
+
+	// OnUnknown, if set, is called with the key of every Unknown Trit
+	// attribute, whether or not it is dropped. Use it to count Unknowns.
+	// It may be called concurrently.
+	OnUnknown func(key string)
+}
+
+// NewLogHandler returns a slog.Handler that rewrites Trit attributes
+// according to opts and passes the records on to next. Attributes nested in
+// groups and those added with Logger.With are handled as well. A nil opts is
+// the same as the zero LogHandlerOptions.
+//
+// Example usage:
+//
+//	var unknown atomic.Int64
+//	h := trit.NewLogHandler(slog.NewJSONHandler(os.Stderr, nil),
+//		&trit.LogHandlerOptions{
+//			Format:      trit.LogBool,
+//			DropUnknown: true,
+//			OnUnknown:   func(string) { unknown.Add(1) },
+//		})
+//	logger := slog.New(h)
+func NewLogHandler(next slog.Handler, opts *LogHandlerOptions) slog.Handler {
+	h := &logHandler{next: next}
+	if opts != nil {
+		h.opts = *opts
+	}
+
+	return h
+}
+
+// logHandler is the slog.Handler returned by NewLogHandler.
+type logHandler struct {
+	next slog.Handler
+	opts LogHandlerOptions
+}
+
+// Enabled implements the slog.Handler interface.
+func (h *logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements the slog.Handler interface.
+func (h *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a, ok := h.attr(a); ok {
+			out.AddAttrs(a)
+		}
+		return true
+	})
+
+	return h.next.Handle(ctx, out)
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &logHandler{next: h.next.WithAttrs(h.attrs(attrs)), opts: h.opts}
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{next: h.next.WithGroup(name), opts: h.opts}
+}
+
+// attrs rewrites a list of attributes, dropping those attr rejects.
+func (h *logHandler) attrs(in []slog.Attr) []slog.Attr {
+	out := make([]slog.Attr, 0, len(in))
+	for _, a := range in {
+		if a, ok := h.attr(a); ok {
+			out = append(out, a)
+		}
+	}
+
+	return out
+}
+
+// attr rewrites a single attribute. It reports false if the attribute must
+// be dropped.
+func (h *logHandler) attr(a slog.Attr) (slog.Attr, bool) {
+	switch a.Value.Kind() {
+	case slog.KindLogValuer:
+		var t Trit
+		switch v := a.Value.Any().(type) {
+		case Trit:
+			t = v
+		case *Trit:
+			if v == nil {
+				return a, true
+			}
+			t = *v
+		default:
+			return a, true
+		}
+
+		if t.IsUnknown() {
+			if h.opts.OnUnknown != nil {
+				h.opts.OnUnknown(a.Key)
+			}
+			if h.opts.DropUnknown {
+				return a, false
+			}
+		}
+
+		return slog.Attr{Key: a.Key, Value: h.opts.Format.Value(t)}, true
+	case slog.KindGroup:
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(h.attrs(a.Value.Group())...)}, true
+	}
+
+	return a, true
+}
@@ -0,0 +1,124 @@
+package trit
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// jsonLogger returns a logger writing JSON lines without time/level/msg
+// noise into the returned buffer, optionally wrapped by NewLogHandler.
+func jsonLogger(opts *LogHandlerOptions) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	var h slog.Handler = slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey ||
+				a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	if opts != nil {
+		h = NewLogHandler(h, opts)
+	}
+
+	return slog.New(h), &buf
+}
+
+// TestLogFormatValue pins the representation of every state in every format.
+func TestLogFormatValue(t *testing.T) {
+	cases := []struct {
+		f    LogFormat
+		want [3]any // False, Unknown, True
+	}{
+		{LogText, [3]any{"False", "Unknown", "True"}},
+		{LogBool, [3]any{false, nil, true}},
+		{LogInt, [3]any{int64(-1), int64(0), int64(1)}},
+	}
+	for _, c := range cases {
+		for i, v := range canonical {
+			if got := c.f.Value(v).Any(); got != c.want[i] {
+				t.Errorf("LogFormat(%d).Value(%s) = %#v, want %#v",
+					c.f, v, got, c.want[i])
+			}
+		}
+	}
+}
+
+// TestLogValueDefault checks that a plain handler, with no middleware,
+// writes a Trit as it did before Trit implemented slog.LogValuer: through
+// MarshalJSON in JSON and MarshalText in text.
+func TestLogValueDefault(t *testing.T) {
+	logger, buf := jsonLogger(nil)
+	logger.Info("", Attr("a", True), Attr("b", Unknown), "c", False, "d", Trit(-5))
+	if got := strings.TrimSpace(buf.String()); got != `{"a":true,"b":null,"c":false,"d":false}` {
+		t.Errorf("JSON output = %s", got)
+	}
+
+	buf.Reset()
+	slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})).Info("", "a", True, "b", Unknown)
+	if got := strings.TrimSpace(buf.String()); got != "a=True b=Unknown" {
+		t.Errorf("text output = %s", got)
+	}
+}
+
+// TestLogHandler checks the middleware: per-handler format, dropping and
+// counting Unknowns, groups and attributes added with Logger.With.
+func TestLogHandler(t *testing.T) {
+	var unknown []string
+	logger, buf := jsonLogger(&LogHandlerOptions{
+		Format:      LogInt,
+		DropUnknown: true,
+		OnUnknown:   func(key string) { unknown = append(unknown, key) },
+	})
+
+	v := True
+	logger.With(Attr("w", Unknown), Attr("x", False)).
+		WithGroup("g").
+		Info("", "a", True, "b", Unknown, "p", &v,
+			slog.Group("sub", Attr("c", Unknown), Attr("d", True)),
+			"plain", "text")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	want := `{"g":{"a":1,"p":1,"plain":"text","sub":{"d":1}},"x":-1}`
+	if b, _ := json.Marshal(got); string(b) != want {
+		t.Errorf("output = %s, want %s", b, want)
+	}
+	if strings.Join(unknown, ",") != "w,b,c" {
+		t.Errorf("OnUnknown keys = %v, want [w b c]", unknown)
+	}
+}
+
+// TestLogHandlerKeepsUnknown checks that Unknowns survive without
+// DropUnknown and that a nil options value is accepted.
+func TestLogHandlerKeepsUnknown(t *testing.T) {
+	logger, buf := jsonLogger(&LogHandlerOptions{Format: LogBool})
+	logger.Info("", Attr("u", Unknown))
+	if got := strings.TrimSpace(buf.String()); got != `{"u":null}` {
+		t.Errorf("output = %s", got)
+	}
+
+	logger, buf = jsonLogger(&LogHandlerOptions{})
+	logger.Info("", Attr("u", Unknown))
+	if got := strings.TrimSpace(buf.String()); got != `{"u":null}` {
+		t.Errorf("output = %s", got)
+	}
+
+	h := NewLogHandler(slog.NewTextHandler(buf, nil), nil)
+	if !h.Enabled(t.Context(), slog.LevelInfo) || h.Enabled(t.Context(), slog.LevelDebug) {
+		t.Errorf("Enabled must delegate to the wrapped handler")
+	}
+}
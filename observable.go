@@ -0,0 +1,203 @@
+package trit
+
+import (
+	"context"
+	"sync"
+)
+
+// Change describes a transition of an Observable from Old to New.
+type Change struct {
+	Old, New Trit
+}
+
+// Observable holds a Trit and notifies subscribers when it changes. Setting
+// the value it already holds is not a change and notifies no one, so a
+// readiness gate can be driven by repeated Set calls without spamming its
+// consumers.
+//
+// The zero value is an Unknown Observable ready to use. An Observable must
+// not be copied after first use.
+//
+// Example usage:
+//
+//	var ready trit.Observable
+//	go func() { ready.Set(probe()) }()
+//	if err := ready.Wait(ctx, trit.True); err != nil {
+//		return err // ctx ended before the gate opened
+//	}
+type Observable struct {
+	// notify serializes Set calls so callbacks observe changes in order.
+	notify sync.Mutex
+
+	mu        sync.Mutex
+	v         Trit
+	changed   chan struct{} // closed and replaced on every change
+	subs      map[*subscription]struct{}
+	callbacks map[*func(Change)]struct{}
+}
+
+// subscription is the coalescing mailbox of one Subscribe call.
+type subscription struct {
+	pending Change
+	has     bool
+	wake    chan struct{} // capacity 1
+}
+
+// NewObservable returns an Observable holding v.
+func NewObservable(v Trit) *Observable {
+	return &Observable{v: v.Val()}
+}
+
+// Get returns the current value.
+func (o *Observable) Get() Trit {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.v
+}
+
+// Set stores v, normalized, and reports whether the value changed. On a
+// change it wakes Wait calls, posts the Change to every subscription and
+// then calls the OnChange callbacks synchronously. Concurrent Set calls are
+// serialized, so callbacks see changes in the order they happened; the order
+// among callbacks is unspecified. Callbacks must not call Set.
+func (o *Observable) Set(v Trit) bool {
+	o.notify.Lock()
+	defer o.notify.Unlock()
+
+	o.mu.Lock()
+	old := o.v
+	v = v.Val()
+	if v == old {
+		o.mu.Unlock()
+		return false
+	}
+
+	o.v = v
+	if o.changed != nil {
+		close(o.changed)
+		o.changed = nil
+	}
+
+	for s := range o.subs {
+		if s.has {
+			s.pending.New = v
+		} else {
+			s.pending, s.has = Change{Old: old, New: v}, true
+		}
+
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	callbacks := make([]func(Change), 0, len(o.callbacks))
+	for fn := range o.callbacks {
+		callbacks = append(callbacks, *fn)
+	}
+	o.mu.Unlock()
+
+	c := Change{Old: old, New: v}
+	for _, fn := range callbacks {
+		fn(c)
+	}
+
+	return true
+}
+
+// Subscribe returns a channel that receives every change of the value until
+// ctx is done, after which the channel is closed.
+//
+// A slow consumer never blocks Set: changes that pile up while it is busy
+// are coalesced into one Change from the first Old to the latest New, and
+// dropped entirely if the value returned to where it started.
+func (o *Observable) Subscribe(ctx context.Context) <-chan Change {
+	s := &subscription{wake: make(chan struct{}, 1)}
+
+	o.mu.Lock()
+	if o.subs == nil {
+		o.subs = make(map[*subscription]struct{})
+	}
+	o.subs[s] = struct{}{}
+	o.mu.Unlock()
+
+	out := make(chan Change)
+	go func() {
+		defer close(out)
+		defer func() {
+			o.mu.Lock()
+			delete(o.subs, s)
+			o.mu.Unlock()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+
+			o.mu.Lock()
+			c, has := s.pending, s.has
+			s.has = false
+			o.mu.Unlock()
+
+			if !has || c.Old == c.New {
+				continue
+			}
+
+			select {
+			case out <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// OnChange registers fn to be called synchronously by Set on every change
+// and returns a function that unregisters it.
+func (o *Observable) OnChange(fn func(Change)) (cancel func()) {
+	key := &fn
+
+	o.mu.Lock()
+	if o.callbacks == nil {
+		o.callbacks = make(map[*func(Change)]struct{})
+	}
+	o.callbacks[key] = struct{}{}
+	o.mu.Unlock()
+
+	return func() {
+		o.mu.Lock()
+		delete(o.callbacks, key)
+		o.mu.Unlock()
+	}
+}
+
+// Wait blocks until the value equals want (compared after normalization) and
+// returns nil, or returns ctx.Err() if ctx is done first. It returns
+// immediately if the value already equals want.
+func (o *Observable) Wait(ctx context.Context, want Trit) error {
+	want = want.Val()
+	for {
+		o.mu.Lock()
+		if o.v == want {
+			o.mu.Unlock()
+			return nil
+		}
+		if o.changed == nil {
+			o.changed = make(chan struct{})
+		}
+		changed := o.changed
+		o.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package trit
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recv reads one Change or fails the test after a generous timeout.
+func recv(t *testing.T, ch <-chan Change) Change {
+	t.Helper()
+	select {
+	case c, ok := <-ch:
+		if !ok {
+			t.Fatal("subscription closed unexpectedly")
+		}
+		return c
+	case <-time.After(time.Second):
+		t.Fatal("no change received")
+	}
+	return Change{}
+}
+
+// until polls o under its lock until cond holds, failing the test after a
+// generous timeout. It replaces sleeps as the handshake with the goroutines
+// behind Subscribe and Wait.
+func until(t *testing.T, o *Observable, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		o.mu.Lock()
+		ok := cond()
+		o.mu.Unlock()
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached")
+		}
+		runtime.Gosched()
+	}
+}
+
+// taken reports whether every subscription of o has picked up its pending
+// change. Call it with o.mu held.
+func taken(o *Observable) bool {
+	for s := range o.subs {
+		if s.has {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestObservableSet checks the transition-only notification contract.
+func TestObservableSet(t *testing.T) {
+	var o Observable
+	if o.Get() != Unknown {
+		t.Fatalf("zero Observable = %s", o.Get())
+	}
+
+	var got []Change
+	cancel := o.OnChange(func(c Change) { got = append(got, c) })
+
+	if o.Set(Unknown) {
+		t.Errorf("Set to the current value must not report a change")
+	}
+	if !o.Set(Trit(3)) || o.Get() != True {
+		t.Errorf("Set(3) -> %s, want True", o.Get())
+	}
+	if o.Set(True) {
+		t.Errorf("repeated Set(True) must not report a change")
+	}
+	o.Set(False)
+
+	want := []Change{{Unknown, True}, {True, False}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("callback changes = %v, want %v", got, want)
+	}
+
+	cancel()
+	o.Set(Unknown)
+	if len(got) != 2 {
+		t.Errorf("callback called after cancel: %v", got)
+	}
+}
+
+// TestObservableSubscribe checks delivery, coalescing for a slow consumer
+// and closing on context cancellation.
+func TestObservableSubscribe(t *testing.T) {
+	o := NewObservable(Unknown)
+	ctx, cancel := context.WithCancel(t.Context())
+	ch := o.Subscribe(ctx)
+
+	// Once the forwarder has taken the first change it blocks delivering
+	// it; everything set meanwhile piles up in its mailbox and must
+	// collapse into one.
+	o.Set(True)
+	until(t, o, func() bool { return taken(o) })
+	o.Set(False)
+	o.Set(Unknown)
+	o.Set(True)
+	o.Set(False)
+	if c := recv(t, ch); c != (Change{Unknown, True}) {
+		t.Errorf("first change = %v, want {Unknown True}", c)
+	}
+	if c := recv(t, ch); c != (Change{True, False}) {
+		t.Errorf("coalesced change = %v, want {True False}", c)
+	}
+
+	// A pile-up that returns to where it started is not a transition.
+	o.Set(Unknown)
+	until(t, o, func() bool { return taken(o) })
+	o.Set(True)
+	o.Set(False)
+	o.Set(Unknown)
+	if c := recv(t, ch); c != (Change{False, Unknown}) {
+		t.Errorf("change = %v, want {False Unknown}", c)
+	}
+	select {
+	case c := <-ch:
+		t.Errorf("round trip produced change %v", c)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			// A change may still be in flight; the channel closes next.
+			_, ok = <-ch
+		}
+		if ok {
+			t.Errorf("channel not closed after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+// TestObservableWait checks Wait for present, future and unreachable values.
+func TestObservableWait(t *testing.T) {
+	o := NewObservable(True)
+	if err := o.Wait(t.Context(), Trit(8)); err != nil {
+		t.Errorf("Wait for the current value = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- o.Wait(t.Context(), False) }()
+	// Wait has parked once it has created the changed channel.
+	until(t, o, func() bool { return o.changed != nil })
+	o.Set(Unknown) // not the awaited value
+	o.Set(False)
+	if err := <-done; err != nil {
+		t.Errorf("Wait(False) = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if err := o.Wait(ctx, True); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait(True) = %v, want DeadlineExceeded", err)
+	}
+}
+
+// TestObservableConcurrent hammers Set, Get, Wait and subscriptions from
+// many goroutines; run with -race. Every delivered change must be a real
+// transition between canonical values.
+func TestObservableConcurrent(t *testing.T) {
+	var o Observable
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var readers sync.WaitGroup
+	for range 4 {
+		ch := o.Subscribe(ctx)
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for c := range ch {
+				if c.Old == c.New || !isCanonical(c.Old) || !isCanonical(c.New) {
+					t.Errorf("bad change %v", c)
+				}
+			}
+		}()
+	}
+
+	var writers sync.WaitGroup
+	for i := range 16 {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for j := range 50 {
+				o.Set(Trit(int8((i+j)%3 - 1)))
+				_ = o.Get()
+			}
+		}()
+	}
+	writers.Wait()
+	o.Set(True)
+	if err := o.Wait(t.Context(), True); err != nil {
+		t.Errorf("Wait: %v", err)
+	}
+
+	cancel()
+	readers.Wait()
+}
@@ -0,0 +1,207 @@
+package trit
+
+import (
+	"iter"
+	"slices"
+)
+
+// BinaryOp is a binary Trit operator passed as a value, such as OpAnd or a
+// caller-defined function. Operators used with Reduce, Fold and Scan must be
+// pure: they are probed on the canonical values to discover their identity
+// and absorbing elements.
+type BinaryOp func(a, b Trit) Trit
+
+// UnaryOp is a unary Trit operator passed as a value, such as OpNot.
+type UnaryOp func(t Trit) Trit
+
+// The binary operators of the Trit methods of the same name.
+var (
+	OpAnd  BinaryOp = Trit.And
+	OpOr   BinaryOp = Trit.Or
+	OpXor  BinaryOp = Trit.Xor
+	OpNand BinaryOp = Trit.Nand
+	OpNor  BinaryOp = Trit.Nor
+	OpNxor BinaryOp = Trit.Nxor
+	OpMin  BinaryOp = Trit.Min
+	OpMax  BinaryOp = Trit.Max
+	OpImp  BinaryOp = Trit.Imp
+	OpNimp BinaryOp = Trit.Nimp
+	OpEq   BinaryOp = Trit.Eq
+	OpNeq  BinaryOp = Trit.Neq
+)
+
+// The unary operators of the Trit methods of the same name.
+var (
+	OpNot UnaryOp = Trit.Not
+	OpMa  UnaryOp = Trit.Ma
+	OpLa  UnaryOp = Trit.La
+	OpIa  UnaryOp = Trit.Ia
+)
+
+// Identity returns the two-sided identity element e of op, for which
+// op(e, x) == x and op(x, e) == x for every x, and whether one exists.
+// For example the identity of OpAnd is True and that of OpXor is False,
+// while OpImp and OpNand have none.
+func (op BinaryOp) Identity() (Trit, bool) {
+	for _, e := range canonicalTrits {
+		if op.isIdentity(e) {
+			return e, true
+		}
+	}
+
+	return Unknown, false
+}
+
+// Absorbing returns the two-sided absorbing element z of op, for which
+// op(z, x) == z and op(x, z) == z for every x, and whether one exists.
+// For example the absorbing element of OpAnd is False, that of OpOr is True
+// and that of OpXor is Unknown, while OpImp has none.
+func (op BinaryOp) Absorbing() (Trit, bool) {
+	for _, z := range canonicalTrits {
+		if op.isLeftAbsorbing(z) && op.isRightAbsorbing(z) {
+			return z, true
+		}
+	}
+
+	return Unknown, false
+}
+
+// canonicalTrits lists the three canonical values in ascending order.
+var canonicalTrits = [3]Trit{False, Unknown, True}
+
+// isIdentity reports whether e is a two-sided identity of op.
+func (op BinaryOp) isIdentity(e Trit) bool {
+	for _, x := range canonicalTrits {
+		if op(e, x).Val() != x || op(x, e).Val() != x {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isLeftAbsorbing reports whether op(z, x) == z for every x.
+func (op BinaryOp) isLeftAbsorbing(z Trit) bool {
+	for _, x := range canonicalTrits {
+		if op(z, x).Val() != z {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isRightAbsorbing reports whether op(x, z) == z for every x.
+func (op BinaryOp) isRightAbsorbing(z Trit) bool {
+	for _, x := range canonicalTrits {
+		if op(x, z).Val() != z {
+			return false
+		}
+	}
+
+	return true
+}
+
+// leftAbsorbing reports, at index v+1, whether a left fold of op that has
+// reached v can stop: no further element can change it. This is weaker than
+// Absorbing, so folds also short-circuit for operators such as OpNimp whose
+// absorbing element is one-sided.
+func (op BinaryOp) leftAbsorbing() (stop [3]bool) {
+	for _, z := range canonicalTrits {
+		stop[z+1] = op.isLeftAbsorbing(z)
+	}
+
+	return stop
+}
+
+// Reduce combines ts from left to right with op:
+// op(op(op(ts[0], ts[1]), ts[2]), …). It stops as soon as the running result
+// is absorbing, so later values are not examined. With a single value it
+// returns that value normalized; with none it returns the identity of op,
+// or Unknown if op has no identity.
+//
+// Example usage:
+//
+//	fmt.Println(trit.Reduce(trit.OpXor, trit.True, trit.True, trit.True))
+//	// Output: True
+func Reduce(op BinaryOp, ts ...Trit) Trit {
+	return ReduceSeq(op, slices.Values(ts))
+}
+
+// ReduceSeq is the iterator form of Reduce. It stops pulling from seq as
+// soon as the running result is absorbing.
+func ReduceSeq(op BinaryOp, seq iter.Seq[Trit]) Trit {
+	absorbing := op.leftAbsorbing()
+	acc, ok := Unknown, false
+	for v := range seq {
+		if ok {
+			acc = op(acc, v).Val()
+		} else {
+			acc, ok = v.Val(), true
+		}
+
+		if absorbing[acc+1] {
+			break
+		}
+	}
+
+	if !ok {
+		acc, _ = op.Identity()
+	}
+
+	return acc
+}
+
+// Fold combines init and the values of seq from left to right with op:
+// op(op(init, v0), v1)…. It stops pulling from seq as soon as the running
+// result is absorbing, and returns init normalized for an empty sequence.
+//
+// Example usage:
+//
+//	ok := trit.Fold(trit.OpAnd, trit.True, slices.Values(checks))
+func Fold(op BinaryOp, init Trit, seq iter.Seq[Trit]) Trit {
+	absorbing := op.leftAbsorbing()
+	acc := init.Val()
+	if absorbing[acc+1] {
+		return acc
+	}
+
+	for v := range seq {
+		if acc = op(acc, v).Val(); absorbing[acc+1] {
+			break
+		}
+	}
+
+	return acc
+}
+
+// Scan returns the running results of reducing seq with op: the first value
+// yielded is the first element of seq (normalized), and each further value
+// is op applied to the previous result and the next element. Scan yields one
+// result per element; once the running result is absorbing, op is no longer
+// called and the absorbing value is repeated.
+//
+// Example usage:
+//
+//	for v := range trit.Scan(trit.OpAnd, slices.Values(ts)) {
+//		fmt.Println(v) // the conjunction of the prefix seen so far
+//	}
+func Scan(op BinaryOp, seq iter.Seq[Trit]) iter.Seq[Trit] {
+	return func(yield func(Trit) bool) {
+		absorbing := op.leftAbsorbing()
+		first := true
+		var acc Trit
+		for v := range seq {
+			switch {
+			case first:
+				acc, first = v.Val(), false
+			case !absorbing[acc+1]:
+				acc = op(acc, v).Val()
+			}
+
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
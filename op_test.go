@@ -0,0 +1,200 @@
+package trit
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// TestOpInstances checks that every exported operator agrees with the Trit
+// method of the same name.
+func TestOpInstances(t *testing.T) {
+	binary := map[string]struct {
+		op     BinaryOp
+		method func(Trit, Trit) Trit
+	}{
+		"And":  {OpAnd, func(a, b Trit) Trit { return a.And(b) }},
+		"Or":   {OpOr, func(a, b Trit) Trit { return a.Or(b) }},
+		"Imp":  {OpImp, func(a, b Trit) Trit { return a.Imp(b) }},
+		"Neq":  {OpNeq, func(a, b Trit) Trit { return a.Neq(b) }},
+		"Xor":  {OpXor, func(a, b Trit) Trit { return a.Xor(b) }},
+		"Nand": {OpNand, func(a, b Trit) Trit { return a.Nand(b) }},
+		"Nor":  {OpNor, func(a, b Trit) Trit { return a.Nor(b) }},
+		"Nxor": {OpNxor, func(a, b Trit) Trit { return a.Nxor(b) }},
+		"Min":  {OpMin, func(a, b Trit) Trit { return a.Min(b) }},
+		"Max":  {OpMax, func(a, b Trit) Trit { return a.Max(b) }},
+		"Nimp": {OpNimp, func(a, b Trit) Trit { return a.Nimp(b) }},
+		"Eq":   {OpEq, func(a, b Trit) Trit { return a.Eq(b) }},
+	}
+	for name, c := range binary {
+		for _, a := range canonical {
+			for _, b := range canonical {
+				if got, want := c.op(a, b), c.method(a, b); got != want {
+					t.Errorf("Op%s(%s,%s) = %s, want %s", name, a, b, got, want)
+				}
+			}
+		}
+	}
+
+	for _, a := range canonical {
+		if OpNot(a) != a.Not() || OpMa(a) != a.Ma() ||
+			OpLa(a) != a.La() || OpIa(a) != a.Ia() {
+			t.Errorf("unary operators disagree with methods for %s", a)
+		}
+	}
+}
+
+// TestOpIdentityAbsorbing pins the probed algebraic elements of the
+// built-in operators.
+func TestOpIdentityAbsorbing(t *testing.T) {
+	const none = Trit(2) // marker for "no such element"
+	cases := []struct {
+		name                string
+		op                  BinaryOp
+		identity, absorbing Trit
+	}{
+		{"And", OpAnd, True, False},
+		{"Or", OpOr, False, True},
+		{"Min", OpMin, True, False},
+		{"Max", OpMax, False, True},
+		{"Xor", OpXor, False, Unknown},
+		{"Eq", OpEq, True, Unknown},
+		{"Neq", OpNeq, False, Unknown},
+		{"Nand", OpNand, none, none},
+		{"Nor", OpNor, none, none},
+		{"Imp", OpImp, none, none},
+		{"Nimp", OpNimp, none, none},
+	}
+	for _, c := range cases {
+		e, ok := c.op.Identity()
+		if !ok {
+			e = none
+		}
+		if e != c.identity {
+			t.Errorf("Op%s.Identity() = %d, want %d", c.name, e, c.identity)
+		}
+
+		z, ok := c.op.Absorbing()
+		if !ok {
+			z = none
+		}
+		if z != c.absorbing {
+			t.Errorf("Op%s.Absorbing() = %d, want %d", c.name, z, c.absorbing)
+		}
+	}
+}
+
+// TestReduce checks Reduce against the n-ary aggregates and the empty and
+// single-value conventions.
+func TestReduce(t *testing.T) {
+	for _, in := range allInputs(4) {
+		if got, want := Reduce(OpAnd, in...), AndAll(in...); got != want {
+			t.Errorf("Reduce(OpAnd, %v) = %s, want %s", in, got, want)
+		}
+		if got, want := Reduce(OpOr, in...), OrAll(in...); got != want {
+			t.Errorf("Reduce(OpOr, %v) = %s, want %s", in, got, want)
+		}
+		if got, want := Reduce(OpXor, in...), XorAll(in...); got != want {
+			t.Errorf("Reduce(OpXor, %v) = %s, want %s", in, got, want)
+		}
+	}
+
+	if got := Reduce(OpImp); got != Unknown {
+		t.Errorf("Reduce(OpImp) = %s, want Unknown (no identity)", got)
+	}
+	if got := Reduce(OpNand, Trit(7)); got != True {
+		t.Errorf("Reduce(OpNand, 7) = %s, want True", got)
+	}
+	// Left fold: (True -> False) -> Unknown = False -> Unknown = True.
+	if got := Reduce(OpImp, True, False, Unknown); got != True {
+		t.Errorf("Reduce(OpImp, T, F, U) = %s, want True", got)
+	}
+}
+
+// TestReduceShortCircuit proves that folds stop pulling at an absorbing
+// running result, including a one-sided one.
+func TestReduceShortCircuit(t *testing.T) {
+	cases := []struct {
+		name       string
+		fn         func(iter.Seq[Trit]) Trit
+		vals       []Trit
+		want       Trit
+		wantPulled int
+	}{
+		{"ReduceSeq And", func(s iter.Seq[Trit]) Trit {
+			return ReduceSeq(OpAnd, s)
+		}, []Trit{True, Unknown, False, True}, False, 3},
+		{"ReduceSeq Nimp", func(s iter.Seq[Trit]) Trit {
+			return ReduceSeq(OpNimp, s)
+		}, []Trit{True, True, False, True}, False, 2},
+		{"ReduceSeq Xor drains", func(s iter.Seq[Trit]) Trit {
+			return ReduceSeq(OpXor, s)
+		}, []Trit{True, True, True}, True, 3},
+		{"ReduceSeq Xor stops at Unknown", func(s iter.Seq[Trit]) Trit {
+			return ReduceSeq(OpXor, s)
+		}, []Trit{True, Unknown, True}, Unknown, 2},
+		{"Fold absorbing init", func(s iter.Seq[Trit]) Trit {
+			return Fold(OpOr, True, s)
+		}, []Trit{False, False}, True, 0},
+		{"Fold Or", func(s iter.Seq[Trit]) Trit {
+			return Fold(OpOr, False, s)
+		}, []Trit{Unknown, True, False}, True, 2},
+	}
+	for _, c := range cases {
+		seq, pulled := trackedSeq(c.vals)
+		if got := c.fn(seq); got != c.want {
+			t.Errorf("%s = %s, want %s", c.name, got, c.want)
+		}
+		if *pulled != c.wantPulled {
+			t.Errorf("%s pulled %d, want %d", c.name, *pulled, c.wantPulled)
+		}
+	}
+
+	if got := Fold(OpAnd, Trit(-9), slices.Values([]Trit(nil))); got != False {
+		t.Errorf("Fold over empty sequence = %s, want normalized init", got)
+	}
+}
+
+// TestScan checks the running results and early termination by the
+// consumer.
+func TestScan(t *testing.T) {
+	in := []Trit{True, Unknown, True, False, True}
+	got := slices.Collect(Scan(OpAnd, slices.Values(in)))
+	want := []Trit{True, Unknown, Unknown, False, False}
+	if !slices.Equal(got, want) {
+		t.Errorf("Scan(OpAnd) = %v, want %v", got, want)
+	}
+
+	got = slices.Collect(Scan(OpXor, slices.Values([]Trit{True, True, True})))
+	if want := []Trit{True, False, True}; !slices.Equal(got, want) {
+		t.Errorf("Scan(OpXor) = %v, want %v", got, want)
+	}
+
+	if n := len(slices.Collect(Scan(OpOr, slices.Values([]Trit(nil))))); n != 0 {
+		t.Errorf("Scan over empty sequence yielded %d values", n)
+	}
+
+	for v := range Scan(OpAnd, slices.Values(in)) {
+		if v != True {
+			t.Errorf("first running result = %s, want True", v)
+		}
+		break
+	}
+}
+
+// TestCustomOp makes sure caller-defined operators are probed like the
+// built-in ones.
+func TestCustomOp(t *testing.T) {
+	// Left projection: every value is left-absorbing, so Reduce returns
+	// the first element without looking further.
+	first := BinaryOp(func(a, _ Trit) Trit { return a })
+	if _, ok := first.Identity(); ok {
+		t.Errorf("left projection must have no identity")
+	}
+
+	seq, pulled := trackedSeq([]Trit{False, True, True})
+	if got := ReduceSeq(first, seq); got != False || *pulled != 1 {
+		t.Errorf("ReduceSeq(first) = %s after %d pulls, want False after 1",
+			got, *pulled)
+	}
+}
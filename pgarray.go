@@ -0,0 +1,536 @@
+package trit
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// This file implements the two PostgreSQL array formats for boolean[]
+// columns, with NULL elements as Unknown: the text format {t,f,NULL} used
+// by database/sql drivers, and the binary format of the wire protocol
+// (array_send/array_recv), used by the pgx codec of the tritpgx module.
+
+// pgBoolOID is the PostgreSQL type OID of boolean, the element type of the
+// binary arrays.
+const pgBoolOID = 16
+
+// Value implements the driver.Valuer interface, writing s in the PostgreSQL
+// array text format, e.g. {t,f,NULL}. A nil Slice is SQL NULL and an empty
+// one is {}.
+//
+// Example usage:
+//
+//	db.Exec("UPDATE checks SET results = $1", trit.Slice{trit.True, trit.Unknown})
+func (s Slice) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	writePGArray(&b, s)
+
+	return b.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, reading a one-dimensional
+// PostgreSQL array in text format. NULL elements become Unknown, elements
+// may be quoted, and a dimension prefix such as [0:1]={t,f} is accepted.
+// SQL NULL scans to a nil Slice; a multi-dimensional array is an error (use
+// Matrix).
+func (s *Slice) Scan(src any) error {
+	text, ok, err := pgArraySource(src)
+	if err != nil || !ok {
+		*s = nil
+		return err
+	}
+
+	dims, values, err := parsePGArray(text)
+	if err != nil {
+		return err
+	}
+	if len(dims) > 1 {
+		return fmt.Errorf("%w: %d-dimensional array scanned into Slice",
+			ErrInvalidTrit, len(dims))
+	}
+
+	*s = values
+	return nil
+}
+
+// Value implements the driver.Valuer interface, writing m in the PostgreSQL
+// array text format, e.g. {{t,f},{NULL,t}}. A nil Matrix is SQL NULL; a
+// Matrix without elements is {}. Rows of different lengths are an error,
+// since PostgreSQL arrays are rectangular.
+func (m Matrix) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	cols, err := m.width()
+	if err != nil || cols == 0 {
+		return "{}", err
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, row := range m {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writePGArray(&b, row)
+	}
+	b.WriteByte('}')
+
+	return b.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, reading a two-dimensional
+// PostgreSQL array in text format. An empty array {} scans to an empty
+// Matrix and SQL NULL to a nil Matrix; arrays of other dimensions are an
+// error.
+func (m *Matrix) Scan(src any) error {
+	text, ok, err := pgArraySource(src)
+	if err != nil || !ok {
+		*m = nil
+		return err
+	}
+
+	dims, values, err := parsePGArray(text)
+	if err != nil {
+		return err
+	}
+
+	*m, err = matrixOf(dims, values)
+	return err
+}
+
+// AppendPGBinary appends s to buf in the PostgreSQL binary array format of
+// boolean[], with NULL for Unknown. Together with ScanPGBinary it backs the
+// binary format of the tritpgx codec.
+//
+// It never fails; the error is for symmetry with Matrix.AppendPGBinary.
+func (s Slice) AppendPGBinary(buf []byte) ([]byte, error) {
+	if len(s) == 0 {
+		return appendPGHeader(buf, nil, false), nil
+	}
+
+	return appendPGElements(appendPGHeader(buf, []int{len(s)}, hasUnknown(s)), s), nil
+}
+
+// ScanPGBinary decodes a PostgreSQL binary boolean[] array of at most one
+// dimension into s. A nil src, which pgx passes for SQL NULL, gives a nil
+// Slice.
+func (s *Slice) ScanPGBinary(src []byte) error {
+	if src == nil {
+		*s = nil
+		return nil
+	}
+
+	dims, values, err := parsePGBinary(src)
+	if err != nil {
+		return err
+	}
+	if len(dims) > 1 {
+		return fmt.Errorf("%w: %d-dimensional array scanned into Slice",
+			ErrInvalidTrit, len(dims))
+	}
+
+	*s = values
+	return nil
+}
+
+// AppendPGBinary appends m to buf in the PostgreSQL binary array format of
+// a two-dimensional boolean[]. Rows of different lengths are an error.
+func (m Matrix) AppendPGBinary(buf []byte) ([]byte, error) {
+	cols, err := m.width()
+	if err != nil {
+		return buf, err
+	}
+	if cols == 0 {
+		return appendPGHeader(buf, nil, false), nil
+	}
+
+	nulls := false
+	for _, row := range m {
+		nulls = nulls || hasUnknown(row)
+	}
+
+	buf = appendPGHeader(buf, []int{len(m), cols}, nulls)
+	for _, row := range m {
+		buf = appendPGElements(buf, row)
+	}
+
+	return buf, nil
+}
+
+// ScanPGBinary decodes a PostgreSQL binary boolean[] array of two
+// dimensions (or an empty array) into m. A nil src gives a nil Matrix.
+func (m *Matrix) ScanPGBinary(src []byte) error {
+	if src == nil {
+		*m = nil
+		return nil
+	}
+
+	dims, values, err := parsePGBinary(src)
+	if err != nil {
+		return err
+	}
+
+	*m, err = matrixOf(dims, values)
+	return err
+}
+
+// width returns the common row length of m.
+func (m Matrix) width() (int, error) {
+	if len(m) == 0 {
+		return 0, nil
+	}
+
+	cols := len(m[0])
+	for i, row := range m {
+		if len(row) != cols {
+			return 0, fmt.Errorf("%w: row %d has %d elements, want %d",
+				ErrInvalidTrit, i, len(row), cols)
+		}
+	}
+
+	return cols, nil
+}
+
+// matrixOf shapes the values of a parsed array into a Matrix.
+func matrixOf(dims []int, values []Trit) (Matrix, error) {
+	switch len(dims) {
+	case 0:
+		return Matrix{}, nil
+	case 2:
+	default:
+		return nil, fmt.Errorf("%w: %d-dimensional array scanned into Matrix",
+			ErrInvalidTrit, len(dims))
+	}
+
+	m := make(Matrix, dims[0])
+	for i := range m {
+		m[i] = values[i*dims[1] : (i+1)*dims[1] : (i+1)*dims[1]]
+	}
+
+	return m, nil
+}
+
+// hasUnknown reports whether s contains an Unknown.
+func hasUnknown(s []Trit) bool {
+	for _, v := range s {
+		if v.IsUnknown() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writePGArray writes one array level in text format.
+func writePGArray(b *strings.Builder, s []Trit) {
+	b.WriteByte('{')
+	for i, v := range s {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		switch v.Val() {
+		case True:
+			b.WriteByte('t')
+		case False:
+			b.WriteByte('f')
+		default:
+			b.WriteString("NULL")
+		}
+	}
+	b.WriteByte('}')
+}
+
+// pgArraySource extracts the text of an array from a driver value. ok is
+// false for SQL NULL.
+func pgArraySource(src any) (text string, ok bool, err error) {
+	switch v := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	}
+
+	return "", false, fmt.Errorf("%w: unsupported array source type %T",
+		ErrInvalidTrit, src)
+}
+
+// pgArrayParser parses the PostgreSQL array text format.
+type pgArrayParser struct {
+	s      string
+	pos    int
+	dims   []int // length of each dimension, -1 until its first array ends
+	leaf   int   // depth of the arrays holding scalars, -1 until known
+	values []Trit
+}
+
+// parsePGArray parses text into its dimensions and row-major values. An
+// empty array has no dimensions.
+func parsePGArray(text string) (dims []int, values []Trit, err error) {
+	p := &pgArrayParser{s: strings.TrimSpace(text), leaf: -1}
+
+	// Skip an explicit dimension decoration such as [0:2]={...}; the bounds
+	// do not change the values.
+	if strings.HasPrefix(p.s, "[") {
+		i := strings.IndexByte(p.s, '=')
+		if i < 0 {
+			return nil, nil, p.errorf("dimension decoration without '='")
+		}
+		p.pos = i + 1
+	}
+
+	p.space()
+	if err := p.array(0); err != nil {
+		return nil, nil, err
+	}
+	if p.space(); p.pos != len(p.s) {
+		return nil, nil, p.errorf("unexpected text after array")
+	}
+
+	// PostgreSQL gives an empty array no dimensions, and nested empty
+	// arrays such as {{},{}} collapse to it.
+	if len(p.values) == 0 {
+		return nil, []Trit{}, nil
+	}
+
+	return p.dims, p.values, nil
+}
+
+func (p *pgArrayParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: malformed array %q at offset %d: %s",
+		ErrInvalidTrit, p.s, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *pgArrayParser) space() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\n\r\v\f", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// array parses one {…} at the given depth.
+func (p *pgArrayParser) array(depth int) error {
+	if p.pos >= len(p.s) || p.s[p.pos] != '{' {
+		return p.errorf("expected '{'")
+	}
+	p.pos++
+
+	p.space()
+	if p.pos < len(p.s) && p.s[p.pos] == '}' {
+		p.pos++
+		return p.dim(depth, 0)
+	}
+
+	for n := 1; ; n++ {
+		// Scalars may only appear at a single depth, below every sub-array.
+		p.space()
+		var err error
+		if p.pos < len(p.s) && p.s[p.pos] == '{' {
+			if p.leaf >= 0 && p.leaf <= depth {
+				return p.errorf("mixed elements and sub-arrays")
+			}
+			err = p.array(depth + 1)
+		} else {
+			if p.leaf >= 0 && p.leaf != depth || len(p.dims) > depth+1 {
+				return p.errorf("mixed elements and sub-arrays")
+			}
+			p.leaf = depth
+			err = p.element()
+		}
+		if err != nil {
+			return err
+		}
+
+		p.space()
+		if p.pos >= len(p.s) {
+			return p.errorf("unterminated array")
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return p.dim(depth, n)
+		default:
+			return p.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+// dim records the length n of dimension depth, or checks it against the
+// length recorded by an earlier array at the same depth.
+func (p *pgArrayParser) dim(depth, n int) error {
+	for len(p.dims) <= depth {
+		p.dims = append(p.dims, -1)
+	}
+
+	switch p.dims[depth] {
+	case -1:
+		p.dims[depth] = n
+	case n:
+	default:
+		return p.errorf("multidimensional arrays must be rectangular")
+	}
+
+	return nil
+}
+
+// element parses one scalar element: NULL, or a quoted or unquoted value
+// read with ParseTrit.
+func (p *pgArrayParser) element() error {
+	var raw string
+	quoted := p.pos < len(p.s) && p.s[p.pos] == '"'
+	if quoted {
+		var b strings.Builder
+		for p.pos++; ; p.pos++ {
+			if p.pos >= len(p.s) {
+				return p.errorf("unterminated quoted element")
+			}
+			c := p.s[p.pos]
+			if c == '"' {
+				p.pos++
+				break
+			}
+			if c == '\\' && p.pos+1 < len(p.s) {
+				p.pos++
+				c = p.s[p.pos]
+			}
+			b.WriteByte(c)
+		}
+		raw = b.String()
+	} else {
+		start := p.pos
+		for p.pos < len(p.s) && strings.IndexByte(",}{\"", p.s[p.pos]) < 0 {
+			p.pos++
+		}
+		raw = strings.TrimSpace(p.s[start:p.pos])
+		if raw == "" {
+			return p.errorf("empty element")
+		}
+	}
+
+	if !quoted && strings.EqualFold(raw, "NULL") {
+		p.values = append(p.values, Unknown)
+		return nil
+	}
+
+	v, err := ParseTrit(raw)
+	if err != nil {
+		return p.errorf("element %q is not a boolean", raw)
+	}
+	p.values = append(p.values, v)
+
+	return nil
+}
+
+// appendPGHeader appends the binary array header: the number of
+// dimensions, the has-NULL flag, the element type, and for each dimension
+// its length and a lower bound of 1.
+func appendPGHeader(buf []byte, dims []int, nulls bool) []byte {
+	flags := uint32(0)
+	if nulls {
+		flags = 1
+	}
+
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(dims)))
+	buf = binary.BigEndian.AppendUint32(buf, flags)
+	buf = binary.BigEndian.AppendUint32(buf, pgBoolOID)
+	for _, n := range dims {
+		buf = binary.BigEndian.AppendUint32(buf, uint32(n))
+		buf = binary.BigEndian.AppendUint32(buf, 1)
+	}
+
+	return buf
+}
+
+// appendPGElements appends binary array elements: a length of -1 for NULL,
+// or a length of 1 and the byte 0 or 1.
+func appendPGElements(buf []byte, s []Trit) []byte {
+	for _, v := range s {
+		switch v.Val() {
+		case True:
+			buf = append(buf, 0, 0, 0, 1, 1)
+		case False:
+			buf = append(buf, 0, 0, 0, 1, 0)
+		default:
+			buf = append(buf, 0xff, 0xff, 0xff, 0xff)
+		}
+	}
+
+	return buf
+}
+
+// parsePGBinary decodes a binary boolean[] array into its dimensions and
+// row-major values.
+func parsePGBinary(src []byte) (dims []int, values []Trit, err error) {
+	fail := func(msg string) error {
+		return fmt.Errorf("%w: malformed binary array: %s", ErrInvalidTrit, msg)
+	}
+
+	if len(src) < 12 {
+		return nil, nil, fail("short header")
+	}
+	ndim := int(binary.BigEndian.Uint32(src))
+	if oid := binary.BigEndian.Uint32(src[8:]); oid != pgBoolOID {
+		return nil, nil, fail(fmt.Sprintf("element type %d is not boolean", oid))
+	}
+	if ndim < 0 || ndim > 6 || len(src) < 12+8*ndim {
+		return nil, nil, fail("bad dimensions")
+	}
+
+	// Every element takes at least 4 bytes, which bounds the element count
+	// by the input size before anything is allocated.
+	rest := src[12:]
+	limit := (len(rest) - 8*ndim) / 4
+	total := 1
+	for range ndim {
+		n := int(int32(binary.BigEndian.Uint32(rest)))
+		if n < 0 || n > limit {
+			return nil, nil, fail("bad dimension length")
+		}
+		dims = append(dims, n)
+		if n != 0 && total > limit/n {
+			return nil, nil, fail("too many elements")
+		}
+		total *= n
+		rest = rest[8:]
+	}
+	if ndim == 0 {
+		total = 0
+	}
+
+	values = make([]Trit, 0, total)
+	for range total {
+		if len(rest) < 4 {
+			return nil, nil, fail("truncated element")
+		}
+		n := int32(binary.BigEndian.Uint32(rest))
+		rest = rest[4:]
+		switch {
+		case n == -1:
+			values = append(values, Unknown)
+		case n == 1 && len(rest) >= 1:
+			values = append(values, Define(rest[0] != 0))
+			rest = rest[1:]
+		default:
+			return nil, nil, fail("bad element length")
+		}
+	}
+	if len(rest) != 0 {
+		return nil, nil, fail("trailing bytes")
+	}
+
+	if total == 0 {
+		return nil, []Trit{}, nil
+	}
+
+	return dims, values, nil
+}
@@ -0,0 +1,184 @@
+package trit
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestSliceText covers the text format in both directions.
+func TestSliceText(t *testing.T) {
+	values := []struct {
+		in   Slice
+		want any
+	}{
+		{nil, nil},
+		{Slice{}, "{}"},
+		{Slice{True, False, Unknown}, "{t,f,NULL}"},
+		{Slice{Trit(5), Trit(-5)}, "{t,f}"},
+	}
+	for _, c := range values {
+		got, err := c.in.Value()
+		if err != nil || got != c.want {
+			t.Errorf("Slice(%v).Value() = %#v, %v; want %#v", c.in, got, err, c.want)
+		}
+	}
+
+	scans := []struct {
+		src  any
+		want Slice
+	}{
+		{nil, nil},
+		{"{}", Slice{}},
+		{"{t,f,NULL}", Slice{True, False, Unknown}},
+		{[]byte("{true,false,null}"), Slice{True, False, Unknown}},
+		{` { "t" , f ,  NULL } `, Slice{True, False, Unknown}},
+		{`{"NULL"}`, Slice{Unknown}}, // quoted text read by ParseTrit
+		{`{"\t"}`, Slice{True}},      // backslash escape
+		{"[0:1]={f,t}", Slice{False, True}},
+		{"{{},{}}", Slice{}},
+	}
+	for _, c := range scans {
+		var s Slice
+		if err := s.Scan(c.src); err != nil {
+			t.Errorf("Scan(%#v) error: %v", c.src, err)
+			continue
+		}
+		if !slices.Equal(s, c.want) || (s == nil) != (c.want == nil) {
+			t.Errorf("Scan(%#v) = %#v, want %#v", c.src, s, c.want)
+		}
+	}
+
+	bad := []any{
+		"", "t,f", "{t,f", "{t,,f}", "{t,maybe-not}", "{t}x", `{"t}`,
+		"{{t},{t,f}}", "{t,{f}}", "{{f},t}", "{{},t}", "{{t},{f}}", 42,
+		"[1:2]{t,f}",
+	}
+	for _, src := range bad {
+		var s Slice
+		if err := s.Scan(src); !errors.Is(err, ErrInvalidTrit) {
+			t.Errorf("Scan(%#v) err = %v, want ErrInvalidTrit", src, err)
+		}
+	}
+}
+
+// TestMatrixText covers two-dimensional arrays.
+func TestMatrixText(t *testing.T) {
+	m := Matrix{{True, False}, {Unknown, True}}
+	got, err := m.Value()
+	if err != nil || got != "{{t,f},{NULL,t}}" {
+		t.Errorf("Matrix.Value() = %#v, %v", got, err)
+	}
+
+	var back Matrix
+	if err := back.Scan(got); err != nil || len(back) != 2 ||
+		!slices.Equal(back[0], m[0]) || !slices.Equal(back[1], m[1]) {
+		t.Errorf("Matrix round-trip = %v, %v", back, err)
+	}
+
+	if err := back.Scan("{{t},{f},{NULL}}"); err != nil || len(back) != 3 || back[2][0] != Unknown {
+		t.Errorf("Scan 3x1 = %v, %v", back, err)
+	}
+	if err := back.Scan("{}"); err != nil || back == nil || len(back) != 0 {
+		t.Errorf("Scan({}) = %#v, %v", back, err)
+	}
+	if err := back.Scan("{t,f}"); !errors.Is(err, ErrInvalidTrit) {
+		t.Errorf("1-D into Matrix: err = %v", err)
+	}
+	if err := back.Scan("{{{t}}}"); !errors.Is(err, ErrInvalidTrit) {
+		t.Errorf("3-D into Matrix: err = %v", err)
+	}
+	if _, err := (Matrix{{True}, {True, False}}).Value(); !errors.Is(err, ErrInvalidTrit) {
+		t.Errorf("ragged Matrix.Value() err = %v", err)
+	}
+	if got, _ := (Matrix{{}, {}}).Value(); got != "{}" {
+		t.Errorf("Matrix of empty rows = %#v, want {}", got)
+	}
+}
+
+// Binary arrays as PostgreSQL's array_send writes them for boolean[]
+// values: ndim, has-NULL flag, element OID 16, then length and lower bound
+// per dimension, then each element as a length (-1 for NULL) and its byte.
+var (
+	// '{t,NULL,f}'::boolean[]
+	wireSlice = []byte{
+		0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 16,
+		0, 0, 0, 3, 0, 0, 0, 1,
+		0, 0, 0, 1, 1,
+		0xff, 0xff, 0xff, 0xff,
+		0, 0, 0, 1, 0,
+	}
+
+	// '{}'::boolean[]
+	wireEmpty = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 16}
+
+	// '{{t,f},{f,t}}'::boolean[]
+	wireMatrix = []byte{
+		0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 16,
+		0, 0, 0, 2, 0, 0, 0, 1,
+		0, 0, 0, 2, 0, 0, 0, 1,
+		0, 0, 0, 1, 1, 0, 0, 0, 1, 0,
+		0, 0, 0, 1, 0, 0, 0, 0, 1, 1,
+	}
+)
+
+// TestPGBinary checks encoding and decoding against the wire bytes.
+func TestPGBinary(t *testing.T) {
+	s := Slice{True, Unknown, False}
+	got, err := s.AppendPGBinary(nil)
+	if err != nil || !bytes.Equal(got, wireSlice) {
+		t.Errorf("AppendPGBinary = % x, %v\nwant % x", got, err, wireSlice)
+	}
+
+	var back Slice
+	if err := back.ScanPGBinary(wireSlice); err != nil || !slices.Equal(back, s) {
+		t.Errorf("ScanPGBinary = %v, %v", back, err)
+	}
+
+	got, _ = Slice{}.AppendPGBinary([]byte{0xaa})
+	if !bytes.Equal(got, append([]byte{0xaa}, wireEmpty...)) {
+		t.Errorf("empty AppendPGBinary must append % x, got % x", wireEmpty, got)
+	}
+	if err := back.ScanPGBinary(wireEmpty); err != nil || back == nil || len(back) != 0 {
+		t.Errorf("ScanPGBinary(empty) = %#v, %v", back, err)
+	}
+	if err := back.ScanPGBinary(nil); err != nil || back != nil {
+		t.Errorf("ScanPGBinary(nil) = %#v, %v", back, err)
+	}
+
+	m := Matrix{{True, False}, {False, True}}
+	got, err = m.AppendPGBinary(nil)
+	if err != nil || !bytes.Equal(got, wireMatrix) {
+		t.Errorf("Matrix.AppendPGBinary = % x, %v\nwant % x", got, err, wireMatrix)
+	}
+	var mb Matrix
+	if err := mb.ScanPGBinary(wireMatrix); err != nil || len(mb) != 2 ||
+		!slices.Equal(mb[1], m[1]) {
+		t.Errorf("Matrix.ScanPGBinary = %v, %v", mb, err)
+	}
+	if err := back.ScanPGBinary(wireMatrix); !errors.Is(err, ErrInvalidTrit) {
+		t.Errorf("2-D into Slice: err = %v", err)
+	}
+
+	// Corrupt inputs must fail cleanly, never panic.
+	for i := range wireSlice {
+		if err := back.ScanPGBinary(wireSlice[:i]); err == nil {
+			t.Errorf("ScanPGBinary of %d-byte prefix succeeded", i)
+		}
+	}
+	int4 := slices.Clone(wireSlice)
+	int4[11] = 23 // int4 element type
+	if err := back.ScanPGBinary(int4); !errors.Is(err, ErrInvalidTrit) {
+		t.Errorf("int4[] array: err = %v", err)
+	}
+
+	// Headers claiming more elements than the input could hold must fail
+	// before allocating them.
+	for _, dims := range [][]int{{70000, 70000, 70000, 70000, 70000, 70000}, {200, 200, 200, 200}} {
+		header := appendPGHeader(nil, dims, false)
+		if err := mb.ScanPGBinary(append(header, make([]byte, 64)...)); !errors.Is(err, ErrInvalidTrit) {
+			t.Errorf("dimensions %v: err = %v", dims, err)
+		}
+	}
+}
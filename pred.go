@@ -0,0 +1,128 @@
+package trit
+
+import "iter"
+
+// Pred is a three-valued predicate over T: a criterion that reports True or
+// False for a value, or Unknown when it cannot decide for that value (for
+// example because the field it inspects is missing). Predicates combine with
+// Kleene semantics, and the combinators skip the second predicate whenever
+// the first one already decides the result.
+//
+// Example usage:
+//
+//	adult := trit.PredFuncOK(func(u User) (bool, bool) {
+//		return u.Age >= 18, u.Age != 0 // age 0 means "not provided"
+//	})
+//	local := trit.PredFunc(func(u User) bool { return u.Country == "UA" })
+//
+//	for u := range trit.FilterSeq(slices.Values(users), adult.And(local)) {
+//		fmt.Println(u.Name)
+//	}
+type Pred[T any] func(v T) Trit
+
+// PredFunc returns a Pred that is True where f returns true and False where
+// it returns false. It never reports Unknown.
+func PredFunc[T any](f func(T) bool) Pred[T] {
+	return func(v T) Trit {
+		return Define(f(v))
+	}
+}
+
+// PredFuncOK returns a Pred built from a function that also reports whether
+// it could decide: the result is Unknown where ok is false, and otherwise
+// True or False according to value.
+func PredFuncOK[T any](f func(T) (value, ok bool)) Pred[T] {
+	return func(v T) Trit {
+		value, ok := f(v)
+		if !ok {
+			return Unknown
+		}
+
+		return Define(value)
+	}
+}
+
+// And returns the Kleene conjunction of p and q. q is not called for a
+// value that p reports False for.
+func (p Pred[T]) And(q Pred[T]) Pred[T] {
+	return func(v T) Trit {
+		a := p(v)
+		if a.IsFalse() {
+			return False
+		}
+
+		return a.And(q(v))
+	}
+}
+
+// Or returns the Kleene disjunction of p and q. q is not called for a value
+// that p reports True for.
+func (p Pred[T]) Or(q Pred[T]) Pred[T] {
+	return func(v T) Trit {
+		a := p(v)
+		if a.IsTrue() {
+			return True
+		}
+
+		return a.Or(q(v))
+	}
+}
+
+// Not returns the negation of p: True and False swap, Unknown stays.
+func (p Pred[T]) Not() Pred[T] {
+	return func(v T) Trit {
+		return p(v).Not()
+	}
+}
+
+// Imp returns the implication p → q, as Trit.Imp. q is not called for a
+// value that p reports False for, since False implies anything.
+func (p Pred[T]) Imp(q Pred[T]) Pred[T] {
+	return func(v T) Trit {
+		a := p(v)
+		if a.IsFalse() {
+			return True
+		}
+
+		return a.Imp(q(v))
+	}
+}
+
+// Xor returns the exclusive or of p and q. Both predicates are always
+// called.
+func (p Pred[T]) Xor(q Pred[T]) Pred[T] {
+	return func(v T) Trit {
+		return p(v).Xor(q(v))
+	}
+}
+
+// FilterSeq yields the values of seq that p reports True for, dropping
+// both the values it rejects and those it cannot decide.
+func FilterSeq[T any](seq iter.Seq[T], p Pred[T]) iter.Seq[T] {
+	return partitionSeq(seq, p, True)
+}
+
+// PartitionSeq splits seq by p into the values it reports True for
+// (matched), False for (unmatched) and Unknown for (undecided). Each
+// returned sequence iterates seq afresh, so seq must be re-iterable (as
+// slices.Values is) and p is called again on every pass.
+//
+// Example usage:
+//
+//	ok, rejected, review := trit.PartitionSeq(slices.Values(orders), valid)
+func PartitionSeq[T any](seq iter.Seq[T], p Pred[T]) (matched, unmatched, undecided iter.Seq[T]) {
+	return partitionSeq(seq, p, True),
+		partitionSeq(seq, p, False),
+		partitionSeq(seq, p, Unknown)
+}
+
+// partitionSeq yields the values of seq that p reports want for.
+func partitionSeq[T any](seq iter.Seq[T], p Pred[T], want Trit) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if p(v).Val() == want && !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package trit
+
+import (
+	"slices"
+	"testing"
+)
+
+// countingPred returns a predicate that ignores its argument, counting calls.
+func countingPred(v Trit, calls *int) Pred[int] {
+	return func(int) Trit {
+		*calls++
+		return v
+	}
+}
+
+// TestPredCombinators checks every combinator against the truth tables and
+// that the second predicate is skipped exactly when the first decides.
+func TestPredCombinators(t *testing.T) {
+	cases := []struct {
+		name string
+		comb func(p, q Pred[int]) Pred[int]
+		want func(a, b Trit) Trit
+		skip Trit // value of p that must not call q; 2 means never skip
+	}{
+		{"And", Pred[int].And, Trit.And, False},
+		{"Or", Pred[int].Or, Trit.Or, True},
+		{"Imp", Pred[int].Imp, Trit.Imp, False},
+		{"Xor", Pred[int].Xor, Trit.Xor, 2},
+	}
+	for _, c := range cases {
+		for _, a := range canonical {
+			for _, b := range canonical {
+				var pc, qc int
+				got := c.comb(countingPred(a, &pc), countingPred(b, &qc))(0)
+				if want := c.want(a, b); got != want {
+					t.Errorf("%s(%s,%s) = %s, want %s", c.name, a, b, got, want)
+				}
+				wantQ := 1
+				if a == c.skip {
+					wantQ = 0
+				}
+				if qc != wantQ {
+					t.Errorf("%s(%s,%s) called q %d times, want %d",
+						c.name, a, b, qc, wantQ)
+				}
+			}
+		}
+	}
+
+	var n int
+	for _, a := range canonical {
+		if got := countingPred(a, &n).Not()(0); got != a.Not() {
+			t.Errorf("Not(%s) = %s", a, got)
+		}
+	}
+}
+
+// TestPredConstructors covers both adapters from Go predicates.
+func TestPredConstructors(t *testing.T) {
+	even := PredFunc(func(n int) bool { return n%2 == 0 })
+	if even(2) != True || even(3) != False {
+		t.Errorf("PredFunc mismatch")
+	}
+
+	positive := PredFuncOK(func(n int) (bool, bool) { return n > 0, n != 0 })
+	if positive(5) != True || positive(-5) != False || positive(0) != Unknown {
+		t.Errorf("PredFuncOK mismatch")
+	}
+}
+
+// TestPredSeq checks filtering and three-way partitioning.
+func TestPredSeq(t *testing.T) {
+	ages := []int{30, 0, 12, 45, 0, 17}
+	adult := PredFuncOK(func(age int) (bool, bool) { return age >= 18, age != 0 })
+
+	got := slices.Collect(FilterSeq(slices.Values(ages), adult))
+	if !slices.Equal(got, []int{30, 45}) {
+		t.Errorf("FilterSeq = %v", got)
+	}
+
+	m, u, d := PartitionSeq(slices.Values(ages), adult)
+	if got := slices.Collect(m); !slices.Equal(got, []int{30, 45}) {
+		t.Errorf("matched = %v", got)
+	}
+	if got := slices.Collect(u); !slices.Equal(got, []int{12, 17}) {
+		t.Errorf("unmatched = %v", got)
+	}
+	if got := slices.Collect(d); !slices.Equal(got, []int{0, 0}) {
+		t.Errorf("undecided = %v", got)
+	}
+
+	for range FilterSeq(slices.Values(ages), adult.Not()) {
+		break // early stop
+	}
+}
@@ -0,0 +1,406 @@
+// Package reactive maintains a dataflow graph of derived trit.Trit values,
+// much like a spreadsheet: input cells hold values set from the outside and
+// formula cells are computed from other cells with the trit operators.
+//
+// Changing an input recomputes only the cells that depend on it, each at
+// most once and in topological order, and stops propagating along a path as
+// soon as a recomputed cell keeps its previous value. Every cell whose value
+// actually changed is reported to the change listeners.
+//
+// Example usage:
+//
+//	g := reactive.New()
+//	db := g.Input("db", trit.Unknown)
+//	cache := g.Input("cache", trit.True)
+//	queue := g.Input("queue", trit.Unknown)
+//	ready := g.And("ready", db, cache, g.Map("queue.ma", trit.Trit.Ma, queue))
+//
+//	db.Set(trit.True)
+//	fmt.Println(ready.Value()) // Output: True
+package reactive
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/goloop/trit/v2"
+)
+
+// ErrCycle is returned by Cell.Define when the new formula would make a cell
+// depend on itself.
+var ErrCycle = errors.New("reactive: dependency cycle")
+
+// ErrNotInput is returned by Cell.Set on a formula cell.
+var ErrNotInput = errors.New("reactive: cell is not an input")
+
+// ErrForeignCell is returned by Cell.Define when a dependency is nil or
+// belongs to another graph. Graph.Formula panics with it.
+var ErrForeignCell = errors.New("reactive: dependency belongs to another graph")
+
+// Event reports that a cell changed from Old to New.
+type Event struct {
+	Cell     *Cell
+	Old, New trit.Trit
+}
+
+// Graph is a set of cells and the dependencies between them. It is safe for
+// concurrent use; listeners observe the events of concurrent updates one
+// update at a time.
+type Graph struct {
+	// emit serializes updates so listeners see events in update order.
+	emit sync.Mutex
+
+	mu        sync.Mutex
+	cells     []*Cell // in topological order
+	listeners map[*func(Event)]struct{}
+}
+
+// Cell is an input or a formula in a Graph.
+type Cell struct {
+	g       *Graph
+	name    string
+	value   trit.Trit
+	formula func(...trit.Trit) trit.Trit // nil for inputs
+	deps    []*Cell
+	users   []*Cell // cells whose formula depends on this one
+	order   int     // position in g.cells
+}
+
+// New returns an empty Graph.
+func New() *Graph {
+	return &Graph{}
+}
+
+// Input adds an input cell holding v.
+func (g *Graph) Input(name string, v trit.Trit) *Cell {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.add(&Cell{g: g, name: name, value: v.Val()})
+}
+
+// Formula adds a cell computed as fn(deps...). The formula receives the
+// dependency values in the given order and its result is normalized.
+// Formula panics with ErrForeignCell if a dependency is nil or belongs to
+// another graph.
+//
+// Example usage:
+//
+//	both := g.Formula("both", func(v ...trit.Trit) trit.Trit {
+//		return v[0].And(v[1])
+//	}, a, b)
+func (g *Graph) Formula(name string, fn func(...trit.Trit) trit.Trit, deps ...*Cell) *Cell {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.owns(deps) {
+		panic(ErrForeignCell)
+	}
+
+	c := &Cell{g: g, name: name, formula: fn, deps: slices.Clone(deps)}
+	c.link()
+	c.value = c.eval()
+
+	return g.add(c)
+}
+
+// Map adds a cell computed by a unary operator, e.g. trit.Trit.Not or
+// trit.Trit.Ma.
+func (g *Graph) Map(name string, op func(trit.Trit) trit.Trit, a *Cell) *Cell {
+	return g.Formula(name, func(v ...trit.Trit) trit.Trit {
+		return op(v[0])
+	}, a)
+}
+
+// Apply adds a cell computed by a binary operator, e.g. trit.Trit.Imp.
+func (g *Graph) Apply(name string, op func(trit.Trit, trit.Trit) trit.Trit, a, b *Cell) *Cell {
+	return g.Formula(name, func(v ...trit.Trit) trit.Trit {
+		return op(v[0], v[1])
+	}, a, b)
+}
+
+// Not adds a cell holding the negation of a.
+func (g *Graph) Not(name string, a *Cell) *Cell {
+	return g.Map(name, trit.Trit.Not, a)
+}
+
+// And adds a cell holding the Kleene conjunction of the cells; with no
+// cells it is True.
+func (g *Graph) And(name string, cells ...*Cell) *Cell {
+	return g.Formula(name, fold(trit.Trit.And, trit.True), cells...)
+}
+
+// Or adds a cell holding the Kleene disjunction of the cells; with no cells
+// it is False.
+func (g *Graph) Or(name string, cells ...*Cell) *Cell {
+	return g.Formula(name, fold(trit.Trit.Or, trit.False), cells...)
+}
+
+// OnChange registers fn to be called with every event and returns a
+// function that unregisters it. Listeners run after an update has fully
+// propagated, in the goroutine that made it, with the events in topological
+// order. They may read cell values but must not update the graph.
+func (g *Graph) OnChange(fn func(Event)) (cancel func()) {
+	key := &fn
+
+	g.mu.Lock()
+	if g.listeners == nil {
+		g.listeners = make(map[*func(Event)]struct{})
+	}
+	g.listeners[key] = struct{}{}
+	g.mu.Unlock()
+
+	return func() {
+		g.mu.Lock()
+		delete(g.listeners, key)
+		g.mu.Unlock()
+	}
+}
+
+// Cells returns the cells of the graph in topological order: every cell
+// comes after the cells it depends on.
+func (g *Graph) Cells() []*Cell {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return slices.Clone(g.cells)
+}
+
+// Name returns the name the cell was created with.
+func (c *Cell) Name() string {
+	return c.name
+}
+
+// Value returns the current value of the cell.
+func (c *Cell) Value() trit.Trit {
+	c.g.mu.Lock()
+	defer c.g.mu.Unlock()
+
+	return c.value
+}
+
+// IsInput reports whether the cell is an input cell.
+func (c *Cell) IsInput() bool {
+	c.g.mu.Lock()
+	defer c.g.mu.Unlock()
+
+	return c.formula == nil
+}
+
+// Set changes the value of an input cell and propagates the change through
+// the graph. It returns ErrNotInput for a formula cell.
+func (c *Cell) Set(v trit.Trit) error {
+	g := c.g
+	g.emit.Lock()
+	defer g.emit.Unlock()
+
+	g.mu.Lock()
+	if c.formula != nil {
+		g.mu.Unlock()
+		return ErrNotInput
+	}
+
+	var events []Event
+	if v = v.Val(); v != c.value {
+		events = append(events, Event{Cell: c, Old: c.value, New: v})
+		c.value = v
+		events = g.propagate(c, events)
+	}
+	listeners := g.snapshot()
+	g.mu.Unlock()
+
+	notify(listeners, events)
+	return nil
+}
+
+// Define turns the cell into a formula cell computed as fn(deps...),
+// replacing its previous formula or input value, and propagates the result.
+// It returns ErrCycle, leaving the graph unchanged, if the cell would come
+// to depend on itself.
+func (c *Cell) Define(fn func(...trit.Trit) trit.Trit, deps ...*Cell) error {
+	g := c.g
+	g.emit.Lock()
+	defer g.emit.Unlock()
+
+	g.mu.Lock()
+	if !g.owns(deps) {
+		g.mu.Unlock()
+		return ErrForeignCell
+	}
+
+	oldFormula, oldDeps := c.formula, c.deps
+	c.unlink()
+	c.formula, c.deps = fn, slices.Clone(deps)
+	c.link()
+
+	if !g.sort() {
+		c.unlink()
+		c.formula, c.deps = oldFormula, oldDeps
+		c.link()
+		g.mu.Unlock()
+		return ErrCycle
+	}
+
+	var events []Event
+	if v := c.eval(); v != c.value {
+		events = append(events, Event{Cell: c, Old: c.value, New: v})
+		c.value = v
+		events = g.propagate(c, events)
+	}
+	listeners := g.snapshot()
+	g.mu.Unlock()
+
+	notify(listeners, events)
+	return nil
+}
+
+// add appends c, whose dependencies already exist, keeping g.cells sorted.
+func (g *Graph) add(c *Cell) *Cell {
+	c.order = len(g.cells)
+	g.cells = append(g.cells, c)
+	return c
+}
+
+// owns reports whether every cell is non-nil and belongs to g.
+func (g *Graph) owns(cells []*Cell) bool {
+	for _, c := range cells {
+		if c == nil || c.g != g {
+			return false
+		}
+	}
+
+	return true
+}
+
+// propagate recomputes the cells downstream of the changed cell in
+// topological order, appending an event for every cell that changes. A cell
+// is recomputed only if one of its dependencies changed.
+func (g *Graph) propagate(changed *Cell, events []Event) []Event {
+	dirty := map[*Cell]bool{}
+	for _, u := range changed.users {
+		dirty[u] = true
+	}
+
+	for _, c := range g.cells[changed.order+1:] {
+		if !dirty[c] {
+			continue
+		}
+
+		if v := c.eval(); v != c.value {
+			events = append(events, Event{Cell: c, Old: c.value, New: v})
+			c.value = v
+			for _, u := range c.users {
+				dirty[u] = true
+			}
+		}
+	}
+
+	return events
+}
+
+// sort recomputes the topological order of g.cells. It reports false, and
+// leaves the order untouched, if the graph has a cycle.
+func (g *Graph) sort() bool {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[*Cell]int, len(g.cells))
+	order := make([]*Cell, 0, len(g.cells))
+
+	var visit func(c *Cell) bool
+	visit = func(c *Cell) bool {
+		switch state[c] {
+		case visiting:
+			return false
+		case done:
+			return true
+		}
+
+		state[c] = visiting
+		for _, d := range c.deps {
+			if !visit(d) {
+				return false
+			}
+		}
+		state[c] = done
+		order = append(order, c)
+		return true
+	}
+
+	for _, c := range g.cells {
+		if !visit(c) {
+			return false
+		}
+	}
+
+	for i, c := range order {
+		c.order = i
+	}
+	g.cells = order
+	return true
+}
+
+// snapshot returns the registered listeners.
+func (g *Graph) snapshot() []func(Event) {
+	listeners := make([]func(Event), 0, len(g.listeners))
+	for fn := range g.listeners {
+		listeners = append(listeners, *fn)
+	}
+
+	return listeners
+}
+
+// eval computes the value of a formula cell from its dependencies.
+func (c *Cell) eval() trit.Trit {
+	if c.formula == nil {
+		return c.value
+	}
+
+	args := make([]trit.Trit, len(c.deps))
+	for i, d := range c.deps {
+		args[i] = d.value
+	}
+
+	return c.formula(args...).Val()
+}
+
+// link registers c as a user of each of its dependencies.
+func (c *Cell) link() {
+	for _, d := range c.deps {
+		d.users = append(d.users, c)
+	}
+}
+
+// unlink removes c from the users of each of its dependencies.
+func (c *Cell) unlink() {
+	for _, d := range c.deps {
+		if i := slices.Index(d.users, c); i >= 0 {
+			d.users = slices.Delete(d.users, i, i+1)
+		}
+	}
+}
+
+// notify delivers events to listeners.
+func notify(listeners []func(Event), events []Event) {
+	for _, e := range events {
+		for _, fn := range listeners {
+			fn(e)
+		}
+	}
+}
+
+// fold returns a formula folding its arguments with op from identity.
+func fold(op func(trit.Trit, trit.Trit) trit.Trit, identity trit.Trit) func(...trit.Trit) trit.Trit {
+	return func(v ...trit.Trit) trit.Trit {
+		acc := identity
+		for _, x := range v {
+			acc = op(acc, x)
+		}
+
+		return acc
+	}
+}
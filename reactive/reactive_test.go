@@ -0,0 +1,219 @@
+package reactive
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/goloop/trit/v2"
+)
+
+// counting wraps a formula and counts its evaluations.
+func counting(n *int, fn func(...trit.Trit) trit.Trit) func(...trit.Trit) trit.Trit {
+	return func(v ...trit.Trit) trit.Trit {
+		*n++
+		return fn(v...)
+	}
+}
+
+// TestDashboard builds the example from the package documentation and
+// checks every derived value against the direct expression.
+func TestDashboard(t *testing.T) {
+	g := New()
+	db := g.Input("db", trit.Unknown)
+	cache := g.Input("cache", trit.True)
+	queue := g.Input("queue", trit.Unknown)
+	ready := g.And("ready", db, cache, g.Map("queue.ma", trit.Trit.Ma, queue))
+	degraded := g.Apply("degraded", trit.Trit.Nimp, cache, db)
+	down := g.Not("down", g.Or("any", db, cache))
+
+	for _, d := range []trit.Trit{trit.False, trit.Unknown, trit.True} {
+		for _, c := range []trit.Trit{trit.False, trit.Unknown, trit.True} {
+			for _, q := range []trit.Trit{trit.False, trit.Unknown, trit.True} {
+				for cell, v := range map[*Cell]trit.Trit{db: d, cache: c, queue: q} {
+					if err := cell.Set(v); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				if want := d.And(c).And(q.Ma()); ready.Value() != want {
+					t.Errorf("ready(%s,%s,%s) = %s, want %s", d, c, q, ready.Value(), want)
+				}
+				if want := c.Nimp(d); degraded.Value() != want {
+					t.Errorf("degraded(%s,%s) = %s, want %s", c, d, degraded.Value(), want)
+				}
+				if want := d.Or(c).Not(); down.Value() != want {
+					t.Errorf("down(%s,%s) = %s, want %s", d, c, down.Value(), want)
+				}
+			}
+		}
+	}
+}
+
+// TestMinimalRecompute checks that only affected cells are recomputed, each
+// once, and that propagation stops at cells whose value did not change.
+func TestMinimalRecompute(t *testing.T) {
+	g := New()
+	a := g.Input("a", trit.True)
+	b := g.Input("b", trit.True)
+
+	var nAB, nB, nTop int
+	ab := g.Formula("ab", counting(&nAB, fold(trit.Trit.Or, trit.False)), a, b)
+	onlyB := g.Formula("onlyB", counting(&nB, fold(trit.Trit.And, trit.True)), b)
+	// A diamond: top depends on ab twice through different paths.
+	top := g.Formula("top", counting(&nTop, fold(trit.Trit.And, trit.True)), ab, onlyB, ab)
+	nAB, nB, nTop = 0, 0, 0
+
+	// a: True -> False keeps ab True (b is True): top must not recompute.
+	a.Set(trit.False)
+	if nAB != 1 || nB != 0 || nTop != 0 {
+		t.Errorf("recomputed ab=%d onlyB=%d top=%d, want 1 0 0", nAB, nB, nTop)
+	}
+
+	// b: True -> False changes ab and onlyB; top is recomputed exactly once.
+	b.Set(trit.False)
+	if nAB != 2 || nB != 1 || nTop != 1 || top.Value() != trit.False {
+		t.Errorf("recomputed ab=%d onlyB=%d top=%d (top=%s), want 2 1 1 (False)",
+			nAB, nB, nTop, top.Value())
+	}
+
+	// Setting the current value is not a change.
+	b.Set(trit.False)
+	if nAB != 2 {
+		t.Errorf("setting the same value recomputed dependents")
+	}
+}
+
+// TestEvents checks that listeners receive exactly the changed cells in
+// topological order.
+func TestEvents(t *testing.T) {
+	g := New()
+	a := g.Input("a", trit.Unknown)
+	b := g.Input("b", trit.False)
+	not := g.Not("not", a)
+	and := g.And("and", not, b)
+	g.Or("or", and, a)
+
+	var got []string
+	cancel := g.OnChange(func(e Event) {
+		got = append(got, fmt.Sprintf("%s:%s->%s", e.Cell.Name(), e.Old, e.New))
+	})
+
+	a.Set(trit.False)
+	want := "[a:Unknown->False not:Unknown->True or:Unknown->False]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("events = %v, want %s", got, want)
+	}
+
+	cancel()
+	a.Set(trit.True)
+	if len(got) != 3 {
+		t.Errorf("listener called after cancel: %v", got)
+	}
+}
+
+// TestDefineCycle checks redefinition, cycle detection and that a rejected
+// definition leaves the graph intact.
+func TestDefineCycle(t *testing.T) {
+	g := New()
+	a := g.Input("a", trit.True)
+	b := g.Not("b", a)
+	c := g.Not("c", b)
+
+	if err := a.Define(fold(trit.Trit.And, trit.True), c); !errors.Is(err, ErrCycle) {
+		t.Fatalf("Define(a <- c) = %v, want ErrCycle", err)
+	}
+	if err := b.Define(fold(trit.Trit.And, trit.True), b); !errors.Is(err, ErrCycle) {
+		t.Fatalf("Define(b <- b) = %v, want ErrCycle", err)
+	}
+
+	// The graph still works as before.
+	if err := a.Set(trit.False); err != nil {
+		t.Fatal(err)
+	}
+	if b.Value() != trit.True || c.Value() != trit.False {
+		t.Errorf("after rejected Define: b=%s c=%s", b.Value(), c.Value())
+	}
+
+	// A legal redefinition that reverses the order of creation.
+	x := g.Input("x", trit.Unknown)
+	if err := b.Define(fold(trit.Trit.Or, trit.False), x); err != nil {
+		t.Fatalf("Define(b <- x): %v", err)
+	}
+	if b.Value() != trit.Unknown || c.Value() != trit.Unknown {
+		t.Errorf("after Define: b=%s c=%s, want Unknown Unknown", b.Value(), c.Value())
+	}
+	x.Set(trit.True)
+	if c.Value() != trit.False {
+		t.Errorf("c = %s, want False through the new dependency", c.Value())
+	}
+
+	// a is no longer a dependency of b.
+	a.Set(trit.True)
+	if b.Value() != trit.True {
+		t.Errorf("b followed its old dependency")
+	}
+
+	if err := b.Set(trit.False); !errors.Is(err, ErrNotInput) {
+		t.Errorf("Set on a formula = %v, want ErrNotInput", err)
+	}
+	if b.IsInput() || !x.IsInput() {
+		t.Errorf("IsInput mismatch")
+	}
+
+	other := New().Input("o", trit.True)
+	if err := b.Define(fold(trit.Trit.Or, trit.False), other); !errors.Is(err, ErrForeignCell) {
+		t.Errorf("Define with foreign cell = %v, want ErrForeignCell", err)
+	}
+
+	order := map[*Cell]int{}
+	for i, cell := range g.Cells() {
+		order[cell] = i
+	}
+	if !(order[x] < order[b] && order[b] < order[c]) {
+		t.Errorf("Cells() not in topological order")
+	}
+}
+
+// TestForeignFormula checks the panic on cross-graph formulas.
+func TestForeignFormula(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrForeignCell {
+			t.Errorf("recover() = %v, want ErrForeignCell", r)
+		}
+	}()
+
+	New().Not("n", New().Input("a", trit.True))
+}
+
+// TestConcurrent updates inputs from several goroutines; run with -race.
+func TestConcurrent(t *testing.T) {
+	g := New()
+	inputs := make([]*Cell, 4)
+	for i := range inputs {
+		inputs[i] = g.Input(fmt.Sprint(i), trit.Unknown)
+	}
+	all := g.And("all", inputs...)
+	g.OnChange(func(e Event) { _ = e.Cell.Name() })
+
+	var wg sync.WaitGroup
+	for i, in := range inputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				in.Set(trit.Trit(int8((i+j)%3 - 1)))
+				_ = all.Value()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, in := range inputs {
+		in.Set(trit.True)
+	}
+	if all.Value() != trit.True {
+		t.Errorf("all = %s, want True", all.Value())
+	}
+}
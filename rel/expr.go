@@ -0,0 +1,114 @@
+package rel
+
+import (
+	"fmt"
+
+	"github.com/goloop/trit/v2"
+)
+
+// Equal is SQL's a = b over column values: Unknown if either is NULL (nil),
+// otherwise whether they are equal. As in PostgreSQL, NaN equals NaN.
+func Equal(a, b any) trit.Trit {
+	if a == nil || b == nil {
+		return trit.Unknown
+	}
+
+	return trit.Define(a == b || (a != a && b != b))
+}
+
+// Get returns a pointer to the value of column col of r, or nil if it is
+// NULL. The pointer plugs into the nullable comparisons of package trit:
+//
+//	adult := func(r rel.Row) trit.Trit {
+//		return trit.GreaterEq(rel.Get[int](r, "age"), &eighteen)
+//	}
+//
+// Get panics with ErrType if the column holds a value of another type.
+func Get[T any](r Row, col string) *T {
+	v := r[col]
+	if v == nil {
+		return nil
+	}
+
+	x, ok := v.(T)
+	if !ok {
+		panic(fmt.Errorf("%w: column %q holds %T", ErrType, col, v))
+	}
+
+	return &x
+}
+
+// Bool returns the condition "column col is true" for a nullable boolean
+// column. The column may hold a bool or a trit.Trit; NULL, like a Trit
+// Unknown, yields Unknown. Bool panics with ErrType for other types.
+func Bool(col string) trit.Pred[Row] {
+	return func(r Row) trit.Trit {
+		return boolValue(r, col)
+	}
+}
+
+// Eq returns the condition col = v, which is Unknown when the column is
+// NULL (or v is nil).
+func Eq(col string, v any) trit.Pred[Row] {
+	return func(r Row) trit.Trit {
+		return Equal(r[col], v)
+	}
+}
+
+// IsNull returns the condition col IS NULL. It is never Unknown.
+func IsNull(col string) trit.Pred[Row] {
+	return func(r Row) trit.Trit {
+		return trit.Define(r[col] == nil)
+	}
+}
+
+// boolValue reads a nullable boolean column.
+func boolValue(r Row, col string) trit.Trit {
+	switch v := r[col].(type) {
+	case nil:
+		return trit.Unknown
+	case bool:
+		return trit.Define(v)
+	case trit.Trit:
+		return v.Val()
+	default:
+		panic(fmt.Errorf("%w: column %q holds %T, not a boolean", ErrType, col, v))
+	}
+}
+
+// When is one WHEN cond THEN value branch of a CASE expression.
+type When struct {
+	Cond trit.Pred[Row]
+	Then any
+}
+
+// CaseExpr is SQL's searched CASE expression. Build it with Case.
+type CaseExpr struct {
+	whens []When
+	els   any
+}
+
+// Case returns the expression CASE WHEN … THEN … END over the given
+// branches. Without Else its value is NULL when no branch applies.
+func Case(whens ...When) CaseExpr {
+	return CaseExpr{whens: whens}
+}
+
+// Else returns the expression with the ELSE value v.
+func (c CaseExpr) Else(v any) CaseExpr {
+	c.els = v
+	return c
+}
+
+// Eval returns the value of the first branch whose condition is True for r.
+// A branch whose condition is Unknown is skipped, just as a False one is;
+// if no branch applies, Eval returns the Else value.
+func (c CaseExpr) Eval(r Row) any {
+	for _, w := range c.whens {
+		if w.Cond(r).IsTrue() {
+			return w.Then
+		}
+	}
+
+	return c.els
+}
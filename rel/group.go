@@ -0,0 +1,134 @@
+package rel
+
+import (
+	"slices"
+
+	"github.com/goloop/trit/v2"
+)
+
+// Aggregate computes one output column of GroupBy from the rows of a group.
+// Build it with Count, CountRows, BoolAnd or BoolOr, or set Fn directly.
+type Aggregate struct {
+	Name string               // the output column
+	Fn   func(rows []Row) any // the value for one group
+}
+
+// Count is COUNT(col) AS name: the number of rows whose col is not NULL.
+func Count(col, name string) Aggregate {
+	return Aggregate{Name: name, Fn: func(rows []Row) any {
+		n := 0
+		for _, r := range rows {
+			if r[col] != nil {
+				n++
+			}
+		}
+
+		return n
+	}}
+}
+
+// CountRows is COUNT(*) AS name: the number of rows, NULLs included.
+func CountRows(name string) Aggregate {
+	return Aggregate{Name: name, Fn: func(rows []Row) any {
+		return len(rows)
+	}}
+}
+
+// BoolAnd is BOOL_AND(col) AS name over a nullable boolean column (see
+// Bool): true if every non-NULL value is true, false if any is false, and
+// NULL (nil) if the group has no non-NULL value. NULLs are ignored, so
+// unlike trit.AndAll the result is never NULL because of a NULL value.
+func BoolAnd(col, name string) Aggregate {
+	return boolAggregate(col, name, trit.True)
+}
+
+// BoolOr is BOOL_OR(col) AS name: true if any non-NULL value is true, false
+// if every one is false, and NULL (nil) if the group has no non-NULL value.
+func BoolOr(col, name string) Aggregate {
+	return boolAggregate(col, name, trit.False)
+}
+
+// boolAggregate folds the non-NULL values of col, starting from the identity
+// of the aggregate (True for BOOL_AND, False for BOOL_OR).
+func boolAggregate(col, name string, identity trit.Trit) Aggregate {
+	return Aggregate{Name: name, Fn: func(rows []Row) any {
+		seen := false
+		for _, r := range rows {
+			v := boolValue(r, col)
+			if v.IsUnknown() {
+				continue
+			}
+
+			seen = true
+			if v != identity {
+				return v.IsTrue()
+			}
+		}
+
+		if !seen {
+			return nil
+		}
+
+		return identity.IsTrue()
+	}}
+}
+
+// GroupBy is SQL's GROUP BY keys with the given aggregates: it returns one
+// row per distinct combination of the key columns, holding the keys
+// followed by the aggregates. As in SQL, NULL keys are not distinct from
+// each other and form a single group. Groups appear in the order of their
+// first row. With no keys, the whole table is one group, even when empty,
+// as SELECT COUNT(*) FROM t returns one row.
+func (t *Table) GroupBy(keys []string, aggs ...Aggregate) *Table {
+	columns := slices.Clone(keys)
+	for _, a := range aggs {
+		columns = append(columns, a.Name)
+	}
+	checkColumns(columns)
+
+	var groups [][]Row
+	for _, r := range t.rows {
+		i := slices.IndexFunc(groups, func(g []Row) bool {
+			return sameKeys(g[0], r, keys)
+		})
+		if i < 0 {
+			groups = append(groups, nil)
+			i = len(groups) - 1
+		}
+		groups[i] = append(groups[i], r)
+	}
+
+	if len(keys) == 0 && len(groups) == 0 {
+		groups = append(groups, nil)
+	}
+
+	result := &Table{columns: columns}
+	for _, g := range groups {
+		row := make(Row, len(columns))
+		for _, k := range keys {
+			row[k] = g[0][k]
+		}
+		for _, a := range aggs {
+			row[a.Name] = a.Fn(g)
+		}
+		result.rows = append(result.rows, row)
+	}
+
+	return result
+}
+
+// sameKeys reports whether a and b are not distinct on every key column,
+// treating NULLs as equal, as GROUP BY does.
+func sameKeys(a, b Row, keys []string) bool {
+	for _, k := range keys {
+		x, y := a[k], b[k]
+		if (x == nil) != (y == nil) {
+			return false
+		}
+		if x != nil && !Equal(x, y).IsTrue() {
+			return false
+		}
+	}
+
+	return true
+}
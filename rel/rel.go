@@ -0,0 +1,251 @@
+// Package rel is a small in-memory relational engine with SQL's
+// three-valued semantics, meant for unit-testing SQL-equivalent business
+// logic without a database.
+//
+// A Table holds rows of nullable columns; a nil value is SQL NULL. Row
+// conditions are trit.Pred[Row] values, so they combine with the Kleene
+// And, Or and Not of package trit. As in SQL:
+//   - Where keeps a row only when its condition is True, dropping both
+//     False and Unknown;
+//   - join conditions built with On never match NULL to NULL, and LeftJoin
+//     pads unmatched rows with NULLs;
+//   - GroupBy puts NULL keys in one group, and the Count, BoolAnd and BoolOr
+//     aggregates ignore NULL values;
+//   - Case picks the first branch whose condition is True.
+//
+// Example usage:
+//
+//	users := rel.NewTable("id", "name", "active").
+//		Insert(1, "ann", true).
+//		Insert(2, "bob", nil)
+//
+//	active := users.Where(rel.Bool("active"))
+//	fmt.Println(active.Len()) // Output: 1 (bob's NULL is not True)
+package rel
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/goloop/trit/v2"
+)
+
+// ErrArity is the panic value of Table.Insert when the number of values
+// does not match the number of columns.
+var ErrArity = errors.New("rel: wrong number of values")
+
+// ErrDuplicateColumn is the panic value of the operations that would give a
+// table two columns of the same name. Qualify the columns with Table.As.
+var ErrDuplicateColumn = errors.New("rel: duplicate column")
+
+// ErrType is the panic value of Get when a column holds a value of another
+// type.
+var ErrType = errors.New("rel: unexpected column type")
+
+// Row maps column names to values. A nil value, or a missing column, is
+// NULL. Values compare with Go's ==, so they must be comparable, and values
+// of different types (int and int64, say) are never equal.
+type Row map[string]any
+
+// Table is an ordered list of rows over a fixed list of columns. The
+// relational operations return new tables and leave their inputs unchanged;
+// rows are shared between tables, so they must not be modified in place.
+type Table struct {
+	columns []string
+	rows    []Row
+}
+
+// NewTable returns an empty table with the given columns. It panics with
+// ErrDuplicateColumn if a column name repeats.
+func NewTable(columns ...string) *Table {
+	checkColumns(columns)
+	return &Table{columns: slices.Clone(columns)}
+}
+
+// Insert appends a row with one value per column, in column order, and
+// returns t for chaining. It panics with ErrArity on a count mismatch.
+func (t *Table) Insert(values ...any) *Table {
+	if len(values) != len(t.columns) {
+		panic(fmt.Errorf("%w: %d values for %d columns",
+			ErrArity, len(values), len(t.columns)))
+	}
+
+	row := make(Row, len(values))
+	for i, c := range t.columns {
+		row[c] = values[i]
+	}
+	t.rows = append(t.rows, row)
+
+	return t
+}
+
+// Columns returns the column names in order.
+func (t *Table) Columns() []string {
+	return slices.Clone(t.columns)
+}
+
+// Rows returns the rows in order. The rows must not be modified.
+func (t *Table) Rows() []Row {
+	return slices.Clone(t.rows)
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Column returns the values of one column, in row order.
+func (t *Table) Column(name string) []any {
+	values := make([]any, len(t.rows))
+	for i, r := range t.rows {
+		values[i] = r[name]
+	}
+
+	return values
+}
+
+// As returns the table with every column renamed to alias.column, so
+// tables with overlapping column names can be joined.
+func (t *Table) As(alias string) *Table {
+	columns := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		columns[i] = alias + "." + c
+	}
+
+	rows := make([]Row, len(t.rows))
+	for i, r := range t.rows {
+		rows[i] = make(Row, len(r))
+		for j, c := range t.columns {
+			rows[i][columns[j]] = r[c]
+		}
+	}
+
+	return &Table{columns: columns, rows: rows}
+}
+
+// Where is SQL's WHERE: it keeps the rows for which cond is True. Rows for
+// which cond is False or Unknown are dropped.
+func (t *Table) Where(cond trit.Pred[Row]) *Table {
+	result := &Table{columns: t.columns}
+	for _, r := range t.rows {
+		if cond(r).IsTrue() {
+			result.rows = append(result.rows, r)
+		}
+	}
+
+	return result
+}
+
+// Select is SQL's SELECT column list: it keeps only the named columns, in
+// the given order.
+func (t *Table) Select(columns ...string) *Table {
+	checkColumns(columns)
+
+	result := &Table{columns: slices.Clone(columns)}
+	for _, r := range t.rows {
+		row := make(Row, len(columns))
+		for _, c := range columns {
+			row[c] = r[c]
+		}
+		result.rows = append(result.rows, row)
+	}
+
+	return result
+}
+
+// Extend adds a computed column, as SELECT *, expr AS name does. It panics
+// with ErrDuplicateColumn if the column already exists.
+//
+// Example usage:
+//
+//	tiers := t.Extend("tier", rel.Case(
+//		rel.When{Cond: rel.Bool("vip"), Then: "gold"},
+//	).Else("basic").Eval)
+func (t *Table) Extend(name string, value func(Row) any) *Table {
+	columns := append(slices.Clone(t.columns), name)
+	checkColumns(columns)
+
+	result := &Table{columns: columns}
+	for _, r := range t.rows {
+		row := make(Row, len(r)+1)
+		for k, v := range r {
+			row[k] = v
+		}
+		row[name] = value(r)
+		result.rows = append(result.rows, row)
+	}
+
+	return result
+}
+
+// JoinCond is a join condition over a pair of rows.
+type JoinCond func(left, right Row) trit.Trit
+
+// On returns the join condition left.leftCol = right.rightCol. Like SQL
+// equality it is Unknown when either value is NULL, so NULL never joins
+// with NULL.
+func On(leftCol, rightCol string) JoinCond {
+	return func(left, right Row) trit.Trit {
+		return Equal(left[leftCol], right[rightCol])
+	}
+}
+
+// Join is SQL's INNER JOIN: it pairs every row of t with every row of right
+// for which on is True. The result has the columns of t followed by those
+// of right; Join panics with ErrDuplicateColumn if a name occurs in both.
+func (t *Table) Join(right *Table, on JoinCond) *Table {
+	return t.join(right, on, false)
+}
+
+// LeftJoin is SQL's LEFT OUTER JOIN: like Join, but a row of t that matches
+// no row of right is kept once, with NULL in every column of right.
+func (t *Table) LeftJoin(right *Table, on JoinCond) *Table {
+	return t.join(right, on, true)
+}
+
+func (t *Table) join(right *Table, on JoinCond, outer bool) *Table {
+	columns := append(slices.Clone(t.columns), right.columns...)
+	checkColumns(columns)
+
+	result := &Table{columns: columns}
+	for _, l := range t.rows {
+		matched := false
+		for _, r := range right.rows {
+			if on(l, r).IsTrue() {
+				matched = true
+				result.rows = append(result.rows, merge(l, r))
+			}
+		}
+
+		if outer && !matched {
+			result.rows = append(result.rows, merge(l, nil))
+		}
+	}
+
+	return result
+}
+
+// merge returns a new row with the values of l and r.
+func merge(l, r Row) Row {
+	row := make(Row, len(l)+len(r))
+	for k, v := range l {
+		row[k] = v
+	}
+	for k, v := range r {
+		row[k] = v
+	}
+
+	return row
+}
+
+// checkColumns panics with ErrDuplicateColumn if a name repeats.
+func checkColumns(columns []string) {
+	seen := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		if seen[c] {
+			panic(fmt.Errorf("%w: %q", ErrDuplicateColumn, c))
+		}
+		seen[c] = true
+	}
+}
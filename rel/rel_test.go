@@ -0,0 +1,211 @@
+package rel
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/goloop/trit/v2"
+)
+
+// fixtures returns a customers and an orders table with NULLs in the
+// interesting places.
+func fixtures() (customers, orders *Table) {
+	customers = NewTable("id", "name", "region", "vip").
+		Insert(1, "ann", "eu", true).
+		Insert(2, "bob", nil, false).
+		Insert(3, "cid", "us", nil).
+		Insert(nil, "dan", "eu", true)
+
+	orders = NewTable("order", "customer", "paid").
+		Insert(10, 1, true).
+		Insert(11, 1, nil).
+		Insert(12, 3, false).
+		Insert(13, nil, true)
+
+	return customers, orders
+}
+
+// names returns the name column as strings.
+func names(t *Table, col string) []string {
+	var out []string
+	for _, v := range t.Column(col) {
+		s, _ := v.(string)
+		out = append(out, s)
+	}
+
+	return out
+}
+
+// TestWhere checks that only rows whose condition is True survive.
+func TestWhere(t *testing.T) {
+	customers, _ := fixtures()
+
+	cases := []struct {
+		name string
+		cond trit.Pred[Row]
+		want []string
+	}{
+		{"vip", Bool("vip"), []string{"ann", "dan"}},
+		{"NOT vip", Bool("vip").Not(), []string{"bob"}},
+		{"region = 'eu'", Eq("region", "eu"), []string{"ann", "dan"}},
+		{"region <> 'eu'", Eq("region", "eu").Not(), []string{"cid"}},
+		{"region IS NULL", IsNull("region"), []string{"bob"}},
+		{"vip OR region = 'us'", Bool("vip").Or(Eq("region", "us")),
+			[]string{"ann", "cid", "dan"}},
+		{"id >= 2", func(r Row) trit.Trit {
+			two := 2
+			return trit.GreaterEq(Get[int](r, "id"), &two)
+		}, []string{"bob", "cid"}},
+	}
+	for _, c := range cases {
+		got := names(customers.Where(c.cond), "name")
+		if !slices.Equal(got, c.want) {
+			t.Errorf("WHERE %s = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestNotInTrap reproduces the classic NOT IN (subquery with NULL) bug:
+// no customer is returned once the subquery yields a NULL.
+func TestNotInTrap(t *testing.T) {
+	customers, orders := fixtures()
+
+	var ids []*int
+	for _, v := range orders.Column("customer") {
+		if v == nil {
+			ids = append(ids, nil)
+			continue
+		}
+		id := v.(int)
+		ids = append(ids, &id)
+	}
+
+	notIn := func(r Row) trit.Trit { return trit.NotInList(Get[int](r, "id"), ids) }
+	if n := customers.Where(notIn).Len(); n != 0 {
+		t.Errorf("NOT IN with a NULL returned %d rows, want 0", n)
+	}
+
+	in := func(r Row) trit.Trit { return trit.InList(Get[int](r, "id"), ids) }
+	if got := names(customers.Where(in), "name"); !slices.Equal(got, []string{"ann", "cid"}) {
+		t.Errorf("IN = %v", got)
+	}
+}
+
+// TestJoins checks that NULL keys never join and that LeftJoin pads with
+// NULLs.
+func TestJoins(t *testing.T) {
+	customers, orders := fixtures()
+	c, o := customers.As("c"), orders.As("o")
+	on := On("c.id", "o.customer")
+
+	inner := c.Join(o, on)
+	if got := names(inner, "c.name"); !slices.Equal(got, []string{"ann", "ann", "cid"}) {
+		t.Errorf("INNER JOIN names = %v", got)
+	}
+
+	left := c.LeftJoin(o, on)
+	if got := names(left, "c.name"); !slices.Equal(got,
+		[]string{"ann", "ann", "bob", "cid", "dan"}) {
+		t.Errorf("LEFT JOIN names = %v", got)
+	}
+	for _, r := range left.Rows() {
+		if r["c.name"] == "dan" && r["o.order"] != nil {
+			t.Errorf("NULL id must not join: %v", r)
+		}
+	}
+	if got := len(left.Columns()); got != 7 {
+		t.Errorf("LEFT JOIN has %d columns, want 7", got)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrDuplicateColumn) {
+			t.Errorf("joining unqualified tables: recover() = %v", err)
+		}
+	}()
+	customers.Join(customers, On("id", "id"))
+}
+
+// TestGroupBy checks grouping of NULL keys and that the aggregates ignore
+// NULL values.
+func TestGroupBy(t *testing.T) {
+	customers, orders := fixtures()
+
+	byRegion := customers.GroupBy([]string{"region"},
+		CountRows("n"), Count("id", "ids"), BoolAnd("vip", "all_vip"),
+		BoolOr("vip", "any_vip"))
+
+	want := []Row{
+		{"region": "eu", "n": 2, "ids": 1, "all_vip": true, "any_vip": true},
+		{"region": nil, "n": 1, "ids": 1, "all_vip": false, "any_vip": false},
+		{"region": "us", "n": 1, "ids": 1, "all_vip": nil, "any_vip": nil},
+	}
+	got := byRegion.Rows()
+	if len(got) != len(want) {
+		t.Fatalf("GROUP BY region: %d groups, want %d", len(got), len(want))
+	}
+	for i := range want {
+		for k, v := range want[i] {
+			if got[i][k] != v {
+				t.Errorf("group %d: %s = %v, want %v", i, k, got[i][k], v)
+			}
+		}
+	}
+
+	paid := orders.GroupBy([]string{"customer"}, BoolAnd("paid", "all_paid"))
+	if got := paid.Column("all_paid"); !slices.Equal(got, []any{true, false, true}) {
+		t.Errorf("BOOL_AND(paid) ignoring NULL = %v", got)
+	}
+
+	empty := NewTable("x").GroupBy(nil, CountRows("n"), BoolOr("x", "any"))
+	if empty.Len() != 1 || empty.Rows()[0]["n"] != 0 || empty.Rows()[0]["any"] != nil {
+		t.Errorf("aggregate over empty table = %v", empty.Rows())
+	}
+}
+
+// TestCase checks CASE WHEN with Unknown conditions and the implicit NULL
+// ELSE.
+func TestCase(t *testing.T) {
+	customers, _ := fixtures()
+
+	tier := Case(
+		When{Cond: Bool("vip"), Then: "gold"},
+		When{Cond: Eq("region", "us"), Then: "us"},
+	)
+
+	withElse := customers.Extend("tier", tier.Else("basic").Eval)
+	if got := names(withElse, "tier"); !slices.Equal(got,
+		[]string{"gold", "basic", "us", "gold"}) {
+		t.Errorf("CASE ... ELSE = %v", got)
+	}
+
+	noElse := customers.Extend("tier", tier.Eval).Where(IsNull("tier"))
+	if got := names(noElse, "name"); !slices.Equal(got, []string{"bob"}) {
+		t.Errorf("CASE without ELSE yields NULL for %v", got)
+	}
+
+	if got := withElse.Select("tier", "name").Columns(); !slices.Equal(got,
+		[]string{"tier", "name"}) {
+		t.Errorf("Select columns = %v", got)
+	}
+}
+
+// TestPanics checks the programming errors.
+func TestPanics(t *testing.T) {
+	expectPanic := func(name string, target error, fn func()) {
+		t.Helper()
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, target) {
+				t.Errorf("%s: recover() = %v, want %v", name, err, target)
+			}
+		}()
+		fn()
+	}
+
+	expectPanic("Insert", ErrArity, func() { NewTable("a", "b").Insert(1) })
+	expectPanic("NewTable", ErrDuplicateColumn, func() { NewTable("a", "a") })
+	expectPanic("Get", ErrType, func() { Get[int](Row{"a": "x"}, "a") })
+	expectPanic("Bool", ErrType, func() { Bool("a")(Row{"a": 1}) })
+}
@@ -0,0 +1,126 @@
+package trit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TritError records why a result was mapped to a Trit instead of a plain
+// bool — usually why it is Unknown. Callers can retrieve it with errors.As
+// and reach the original cause through Unwrap.
+type TritError struct {
+	// Value is the Trit the failed result was mapped to.
+	Value Trit
+
+	// Err is the cause, e.g. context.DeadlineExceeded.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TritError) Error() string {
+	return fmt.Sprintf("trit: result is %s: %v", e.Value, e.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (e *TritError) Unwrap() error {
+	return e.Err
+}
+
+// FromResult converts the common (bool, error) result of a check into a
+// Trit: any error yields Unknown, otherwise ok maps to True or False.
+//
+// Example usage:
+//
+//	t := trit.FromResult(cache.Ping(ctx))
+//	fmt.Println(t.String()) // Output: Unknown if Ping failed
+func FromResult(ok bool, err error) Trit {
+	if err != nil {
+		return Unknown
+	}
+
+	if ok {
+		return True
+	}
+
+	return False
+}
+
+// FromResultErr is FromResult that also returns the reason for an Unknown
+// result: a *TritError wrapping err, or nil when err is nil.
+func FromResultErr(ok bool, err error) (Trit, error) {
+	if err != nil {
+		return Unknown, &TritError{Value: Unknown, Err: err}
+	}
+
+	return FromResult(ok, nil), nil
+}
+
+// FromResultStrict is FromResult with a classifier that decides what an
+// error means. Some errors are answers rather than failures — a "not found"
+// may well mean False — while others, like a timeout, leave the question
+// open. The classifier's result is normalized; a nil classifier maps every
+// error to Unknown, like FromResult.
+//
+// Example usage:
+//
+//	t := trit.FromResultStrict(ok, err, func(err error) trit.Trit {
+//		if errors.Is(err, fs.ErrNotExist) {
+//			return trit.False
+//		}
+//		return trit.Unknown
+//	})
+func FromResultStrict(ok bool, err error, classify func(error) Trit) Trit {
+	if err == nil {
+		return FromResult(ok, nil)
+	}
+
+	if classify == nil {
+		return Unknown
+	}
+
+	return classify(err).Val()
+}
+
+// Try calls fn with a context bounded by timeout and converts its result
+// with FromResult. If the deadline passes (or ctx is done) before fn returns,
+// Try returns Unknown without waiting for fn. A non-positive timeout adds no
+// deadline of its own.
+//
+// Example usage:
+//
+//	healthy := trit.Try(ctx, 200*time.Millisecond, db.Healthy)
+func Try(ctx context.Context, timeout time.Duration, fn func(context.Context) (bool, error)) Trit {
+	v, _ := TryErr(ctx, timeout, fn)
+	return v
+}
+
+// TryErr is Try that also returns why the result is Unknown: a *TritError
+// wrapping the error returned by fn or, when fn did not finish in time, the
+// context's error (context.DeadlineExceeded or context.Canceled).
+func TryErr(ctx context.Context, timeout time.Duration, fn func(context.Context) (bool, error)) (Trit, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		ok  bool
+		err error
+	}
+
+	// Buffered, so fn can finish and exit after Try has given up on it.
+	done := make(chan result, 1)
+	go func() {
+		ok, err := fn(ctx)
+		done <- result{ok, err}
+	}()
+
+	select {
+	case r := <-done:
+		return FromResultErr(r.ok, r.err)
+	case <-ctx.Done():
+		return Unknown, &TritError{Value: Unknown, Err: ctx.Err()}
+	}
+}
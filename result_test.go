@@ -0,0 +1,106 @@
+package trit
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+var errBackend = errors.New("backend unavailable")
+
+// TestFromResult covers the plain (bool, error) bridge and its Err form.
+func TestFromResult(t *testing.T) {
+	cases := []struct {
+		ok   bool
+		err  error
+		want Trit
+	}{
+		{true, nil, True},
+		{false, nil, False},
+		{true, errBackend, Unknown},
+		{false, errBackend, Unknown},
+	}
+	for _, c := range cases {
+		if got := FromResult(c.ok, c.err); got != c.want {
+			t.Errorf("FromResult(%v, %v) = %s, want %s", c.ok, c.err, got, c.want)
+		}
+
+		got, err := FromResultErr(c.ok, c.err)
+		if got != c.want || (err == nil) != (c.err == nil) {
+			t.Errorf("FromResultErr(%v, %v) = %s, %v", c.ok, c.err, got, err)
+		}
+		if c.err != nil {
+			var te *TritError
+			if !errors.As(err, &te) || te.Value != Unknown || !errors.Is(err, c.err) {
+				t.Errorf("FromResultErr error = %#v, want *TritError wrapping cause", err)
+			}
+		}
+	}
+}
+
+// TestFromResultStrict checks that the classifier decides error outcomes
+// and that its result is normalized.
+func TestFromResultStrict(t *testing.T) {
+	classify := func(err error) Trit {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Trit(-5)
+		}
+		return Unknown
+	}
+
+	if got := FromResultStrict(true, nil, classify); got != True {
+		t.Errorf("no error = %s, want True", got)
+	}
+	if got := FromResultStrict(true, fs.ErrNotExist, classify); got != False {
+		t.Errorf("not found = %s, want False", got)
+	}
+	if got := FromResultStrict(true, errBackend, classify); got != Unknown {
+		t.Errorf("other error = %s, want Unknown", got)
+	}
+	if got := FromResultStrict(false, fs.ErrNotExist, nil); got != Unknown {
+		t.Errorf("nil classifier = %s, want Unknown", got)
+	}
+}
+
+// TestTry covers success, error, and a check that outlives its deadline.
+func TestTry(t *testing.T) {
+	ok := func(context.Context) (bool, error) { return true, nil }
+	if got := Try(t.Context(), time.Second, ok); got != True {
+		t.Errorf("Try(ok) = %s, want True", got)
+	}
+
+	failing := func(context.Context) (bool, error) { return true, errBackend }
+	v, err := TryErr(t.Context(), 0, failing)
+	if v != Unknown || !errors.Is(err, errBackend) {
+		t.Errorf("TryErr(failing) = %s, %v", v, err)
+	}
+
+	// The check ignores its context entirely; Try must not wait for it.
+	release := make(chan struct{})
+	defer close(release)
+	stuck := func(context.Context) (bool, error) {
+		<-release
+		return true, nil
+	}
+
+	start := time.Now()
+	v, err = TryErr(t.Context(), 10*time.Millisecond, stuck)
+	if v != Unknown || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TryErr(stuck) = %s, %v; want Unknown, DeadlineExceeded", v, err)
+	}
+	var te *TritError
+	if !errors.As(err, &te) {
+		t.Errorf("TryErr(stuck) error %T is not a *TritError", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Try waited for a check that ignored its context")
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := TryErr(ctx, 0, stuck); !errors.Is(err, context.Canceled) {
+		t.Errorf("TryErr(cancelled) err = %v, want Canceled", err)
+	}
+}
@@ -0,0 +1,256 @@
+// Package seq provides iter.Seq combinators for streams of trit.Trit values:
+// conversion, element-wise operators, filtering, partitioning, rolling
+// windows and debouncing. Every combinator is lazy and pulls from its input
+// only as far as its consumer asks.
+//
+// The Seq2 forms (Map2, FilterTrue2, FilterKnown2, Partition2) carry a key,
+// such as the index from slices.All or a sensor name from maps.All, through
+// the pipeline.
+//
+// Example usage:
+//
+//	readings := seq.Map(slices.Values([]int{1, 1, 0, 1, -1, 1}))
+//	for v := range seq.WindowMajority(readings, 3) {
+//		fmt.Println(v) // True, True, Unknown, True
+//	}
+package seq
+
+import (
+	"iter"
+
+	"github.com/goloop/trit/v2"
+)
+
+// Map converts a sequence of any Logicable values to Trit values, as
+// trit.Define does for a single value.
+func Map[T trit.Logicable](s iter.Seq[T]) iter.Seq[trit.Trit] {
+	return func(yield func(trit.Trit) bool) {
+		for v := range s {
+			if !yield(trit.Define(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 is the Seq2 form of Map: it converts the values and keeps the keys.
+func Map2[K any, T trit.Logicable](s iter.Seq2[K, T]) iter.Seq2[K, trit.Trit] {
+	return func(yield func(K, trit.Trit) bool) {
+		for k, v := range s {
+			if !yield(k, trit.Define(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Apply yields op applied to each value of s.
+//
+// Example usage:
+//
+//	inverted := seq.Apply(trit.OpNot, readings)
+func Apply(op trit.UnaryOp, s iter.Seq[trit.Trit]) iter.Seq[trit.Trit] {
+	return func(yield func(trit.Trit) bool) {
+		for v := range s {
+			if !yield(op(v)) {
+				return
+			}
+		}
+	}
+}
+
+// ZipWith yields op applied to the pairs of values of a and b taken in
+// step. It stops when either sequence ends.
+//
+// Example usage:
+//
+//	both := seq.ZipWith(trit.OpAnd, primary, replica)
+func ZipWith(op trit.BinaryOp, a, b iter.Seq[trit.Trit]) iter.Seq[trit.Trit] {
+	return func(yield func(trit.Trit) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for x := range a {
+			y, ok := next()
+			if !ok || !yield(op(x, y)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterTrue yields only the True values of s.
+func FilterTrue(s iter.Seq[trit.Trit]) iter.Seq[trit.Trit] {
+	return filter(s, trit.Trit.IsTrue)
+}
+
+// FilterKnown yields only the True and False values of s, dropping the
+// Unknown ones, such as missing sensor readings.
+func FilterKnown(s iter.Seq[trit.Trit]) iter.Seq[trit.Trit] {
+	return filter(s, isKnown)
+}
+
+// FilterTrue2 is the Seq2 form of FilterTrue; the keys of the True values
+// are kept.
+func FilterTrue2[K any](s iter.Seq2[K, trit.Trit]) iter.Seq2[K, trit.Trit] {
+	return filter2(s, trit.Trit.IsTrue)
+}
+
+// FilterKnown2 is the Seq2 form of FilterKnown.
+func FilterKnown2[K any](s iter.Seq2[K, trit.Trit]) iter.Seq2[K, trit.Trit] {
+	return filter2(s, isKnown)
+}
+
+// Partition splits s by the classification f gives each value into three
+// sequences: the values classified True, False and Unknown. It is
+// trit.PartitionSeq for a plain function, with the same caveat: each
+// returned sequence iterates s afresh, so s must be re-iterable (as
+// slices.Values is) and f is called again on every pass.
+//
+// Example usage:
+//
+//	ok, failed, pending := seq.Partition(slices.Values(jobs), Job.Status)
+func Partition[V any](s iter.Seq[V], f func(V) trit.Trit) (trues, falses, unknowns iter.Seq[V]) {
+	return trit.PartitionSeq(s, trit.Pred[V](f))
+}
+
+// Partition2 splits the keys of s by their values into three sequences: the
+// keys of the True, False and Unknown values. As with Partition, each
+// returned sequence iterates s afresh.
+//
+// Example usage:
+//
+//	up, down, silent := seq.Partition2(maps.All(sensors))
+func Partition2[K any](s iter.Seq2[K, trit.Trit]) (trues, falses, unknowns iter.Seq[K]) {
+	part := func(want trit.Trit) iter.Seq[K] {
+		return func(yield func(K) bool) {
+			for k, v := range s {
+				if v.Val() == want && !yield(k) {
+					return
+				}
+			}
+		}
+	}
+
+	return part(trit.True), part(trit.False), part(trit.Unknown)
+}
+
+// Window yields, for every position of s from the n-th value on, a
+// trit.Tally of the last n values. Each step costs O(1) whatever the window
+// size. A sequence shorter than n yields nothing; an n below 1 is treated
+// as 1.
+func Window(s iter.Seq[trit.Trit], n int) iter.Seq[trit.Tally] {
+	n = max(n, 1)
+	return func(yield func(trit.Tally) bool) {
+		ring := make([]trit.Trit, n)
+		var counts [3]int // indexed by Val()+1
+		i := 0
+		for v := range s {
+			v = v.Val()
+			if i >= n {
+				counts[ring[i%n]+1]--
+			}
+			ring[i%n] = v
+			counts[v+1]++
+			i++
+
+			if i < n {
+				continue
+			}
+
+			var t trit.Tally
+			t.AddN(trit.False, counts[0])
+			t.AddN(trit.Unknown, counts[1])
+			t.AddN(trit.True, counts[2])
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// WindowMajority yields the rolling trit.Majority of the last n values of
+// s, starting once n values have been seen.
+func WindowMajority(s iter.Seq[trit.Trit], n int) iter.Seq[trit.Trit] {
+	return mapTally(Window(s, n), trit.Tally.Majority)
+}
+
+// WindowConsensus yields the rolling trit.Consensus of the last n values of
+// s, starting once n values have been seen.
+func WindowConsensus(s iter.Seq[trit.Trit], n int) iter.Seq[trit.Trit] {
+	return mapTally(Window(s, n), trit.Tally.Consensus)
+}
+
+// Debounce yields a value only once it has been read n times in a row and
+// differs from the value yielded before it, so a flapping input produces no
+// output until it settles. The first value is yielded after its first n
+// consecutive readings. An n below 1 is treated as 1, which suppresses only
+// repeats.
+//
+// Example usage:
+//
+//	// F F T F T T T -> False, True
+//	for v := range seq.Debounce(readings, 2) {
+//		fmt.Println(v)
+//	}
+func Debounce(s iter.Seq[trit.Trit], n int) iter.Seq[trit.Trit] {
+	n = max(n, 1)
+	return func(yield func(trit.Trit) bool) {
+		var last, run trit.Trit
+		emitted := false
+		streak := 0
+		for v := range s {
+			v = v.Val()
+			if streak > 0 && v == run {
+				streak++
+			} else {
+				run, streak = v, 1
+			}
+
+			if streak == n && (!emitted || run != last) {
+				last, emitted = run, true
+				if !yield(run) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// isKnown reports whether t is True or False.
+func isKnown(t trit.Trit) bool {
+	return !t.IsUnknown()
+}
+
+// filter yields the values of s for which keep returns true.
+func filter(s iter.Seq[trit.Trit], keep func(trit.Trit) bool) iter.Seq[trit.Trit] {
+	return func(yield func(trit.Trit) bool) {
+		for v := range s {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// filter2 yields the pairs of s whose value keep returns true for.
+func filter2[K any](s iter.Seq2[K, trit.Trit], keep func(trit.Trit) bool) iter.Seq2[K, trit.Trit] {
+	return func(yield func(K, trit.Trit) bool) {
+		for k, v := range s {
+			if keep(v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// mapTally yields f applied to each tally of s.
+func mapTally(s iter.Seq[trit.Tally], f func(trit.Tally) trit.Trit) iter.Seq[trit.Trit] {
+	return func(yield func(trit.Trit) bool) {
+		for t := range s {
+			if !yield(f(t)) {
+				return
+			}
+		}
+	}
+}
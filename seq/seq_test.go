@@ -0,0 +1,167 @@
+package seq
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/goloop/trit/v2"
+)
+
+const (
+	T = trit.True
+	F = trit.False
+	U = trit.Unknown
+)
+
+// values returns a re-iterable sequence over vals.
+func values(vals ...trit.Trit) iter.Seq[trit.Trit] {
+	return slices.Values(vals)
+}
+
+// expect fails the test unless s yields exactly want.
+func expect[V comparable](t *testing.T, name string, s iter.Seq[V], want ...V) {
+	t.Helper()
+	if got := slices.Collect(s); !slices.Equal(got, want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+// TestMap covers conversion and operator application.
+func TestMap(t *testing.T) {
+	expect(t, "Map", Map(slices.Values([]int{5, 0, -2})), T, U, F)
+	expect(t, "Apply", Apply(trit.OpNot, values(T, U, F)), F, U, T)
+
+	got := maps.Collect(Map2(slices.All([]bool{true, false})))
+	if got[0] != T || got[1] != F || len(got) != 2 {
+		t.Errorf("Map2 = %v", got)
+	}
+}
+
+// TestZipWith checks element-wise application and that zipping stops at
+// the shorter sequence on either side.
+func TestZipWith(t *testing.T) {
+	expect(t, "ZipWith", ZipWith(trit.OpAnd, values(T, T, F), values(T, U, T)),
+		T, U, F)
+	expect(t, "ZipWith short b", ZipWith(trit.OpOr, values(F, F, F), values(T)), T)
+	expect(t, "ZipWith short a", ZipWith(trit.OpOr, values(F), values(T, T)), T)
+
+	for range ZipWith(trit.OpXor, values(T, T), values(T, T)) {
+		break // early stop must release the pulled sequence
+	}
+}
+
+// TestFilter covers the plain and keyed filters.
+func TestFilter(t *testing.T) {
+	in := []trit.Trit{T, U, F, trit.Trit(3), U}
+	expect(t, "FilterTrue", FilterTrue(values(in...)), T, trit.Trit(3))
+	expect(t, "FilterKnown", FilterKnown(values(in...)), T, F, trit.Trit(3))
+
+	var keys []int
+	for i := range FilterKnown2(slices.All(in)) {
+		keys = append(keys, i)
+	}
+	if !slices.Equal(keys, []int{0, 2, 3}) {
+		t.Errorf("FilterKnown2 keys = %v", keys)
+	}
+
+	keys = keys[:0]
+	for i := range FilterTrue2(slices.All(in)) {
+		keys = append(keys, i)
+	}
+	if !slices.Equal(keys, []int{0, 3}) {
+		t.Errorf("FilterTrue2 keys = %v", keys)
+	}
+}
+
+// TestPartition checks both partition forms, including re-iteration.
+func TestPartition(t *testing.T) {
+	words := []string{"yes", "", "no", "maybe", "on"}
+	classify := func(s string) trit.Trit {
+		v, _ := trit.ParseTrit(s)
+		return v
+	}
+
+	tr, fa, un := Partition(slices.Values(words), classify)
+	expect(t, "trues", tr, "yes", "on")
+	expect(t, "falses", fa, "no")
+	expect(t, "unknowns", un, "", "maybe")
+	expect(t, "trues again", tr, "yes", "on")
+
+	tk, fk, uk := Partition2(slices.All([]trit.Trit{U, T, F, T}))
+	expect(t, "true keys", tk, 1, 3)
+	expect(t, "false keys", fk, 2)
+	expect(t, "unknown keys", uk, 0)
+}
+
+// TestWindow compares the rolling aggregates with the slice functions over
+// every window.
+func TestWindow(t *testing.T) {
+	in := []trit.Trit{T, T, U, T, F, F, F, U, T, T}
+	for n := 1; n <= len(in)+1; n++ {
+		var wantM, wantC []trit.Trit
+		for i := n; i <= len(in); i++ {
+			wantM = append(wantM, trit.Majority(in[i-n:i]...))
+			wantC = append(wantC, trit.Consensus(in[i-n:i]...))
+		}
+		expect(t, "WindowMajority", WindowMajority(values(in...), n), wantM...)
+		expect(t, "WindowConsensus", WindowConsensus(values(in...), n), wantC...)
+	}
+
+	for c := range Window(values(T, F, U), 0) {
+		if c.Len() != 1 {
+			t.Errorf("Window(0) tally of %d values, want 1", c.Len())
+		}
+	}
+}
+
+// TestDebounce checks that only settled changes are emitted.
+func TestDebounce(t *testing.T) {
+	cases := []struct {
+		n    int
+		in   []trit.Trit
+		want []trit.Trit
+	}{
+		{2, []trit.Trit{F, F, T, F, T, T, T}, []trit.Trit{F, T}},
+		{3, []trit.Trit{T, T, F, T, T, T, U, U, U, U}, []trit.Trit{T, U}},
+		{3, []trit.Trit{T, T, F, F, U, U}, nil},
+		{1, []trit.Trit{T, T, F, F, T}, []trit.Trit{T, F, T}},
+		{0, []trit.Trit{U, U}, []trit.Trit{U}},
+		{2, []trit.Trit{T, T, F, F, T, T}, []trit.Trit{T, F, T}},
+	}
+	for _, c := range cases {
+		expect(t, "Debounce", Debounce(values(c.in...), c.n), c.want...)
+	}
+}
+
+// TestEarlyStop makes sure no combinator keeps pulling after its consumer
+// stops.
+func TestEarlyStop(t *testing.T) {
+	pulled := 0
+	infinite := func(yield func(trit.Trit) bool) {
+		for {
+			pulled++
+			if !yield(T) {
+				return
+			}
+		}
+	}
+
+	for name, s := range map[string]iter.Seq[trit.Trit]{
+		"Apply":          Apply(trit.OpMa, infinite),
+		"FilterTrue":     FilterTrue(infinite),
+		"FilterKnown":    FilterKnown(infinite),
+		"ZipWith":        ZipWith(trit.OpAnd, infinite, infinite),
+		"WindowMajority": WindowMajority(infinite, 4),
+		"Debounce":       Debounce(infinite, 1),
+	} {
+		pulled = 0
+		for range s {
+			break
+		}
+		if pulled == 0 || pulled > 8 {
+			t.Errorf("%s pulled %d values for one result", name, pulled)
+		}
+	}
+}
@@ -60,6 +60,48 @@ func TestSeqShortCircuit(t *testing.T) {
 			want:       False,
 			wantPulled: 3,
 		},
+		{
+			name:       "CoalesceSeq stops at first definite value",
+			fn:         CoalesceSeq[Trit],
+			vals:       []Trit{Unknown, Unknown, False, True},
+			want:       False,
+			wantPulled: 3,
+		},
+		{
+			name:       "AndAllSeq stops at first False",
+			fn:         AndAllSeq[Trit],
+			vals:       []Trit{True, Unknown, False, True},
+			want:       False,
+			wantPulled: 3,
+		},
+		{
+			name:       "OrAllSeq stops at first True",
+			fn:         OrAllSeq[Trit],
+			vals:       []Trit{Unknown, False, True, False},
+			want:       True,
+			wantPulled: 3,
+		},
+		{
+			name:       "XorAllSeq stops at first Unknown",
+			fn:         XorAllSeq[Trit],
+			vals:       []Trit{True, Unknown, True, True},
+			want:       Unknown,
+			wantPulled: 2,
+		},
+		{
+			name:       "EqAllSeq stops once both True and False are seen",
+			fn:         EqAllSeq[Trit],
+			vals:       []Trit{Unknown, True, False, True},
+			want:       False,
+			wantPulled: 3,
+		},
+		{
+			name:       "ImpChainSeq stops at a False after a True",
+			fn:         ImpChainSeq[Trit],
+			vals:       []Trit{False, True, Unknown, False, True},
+			want:       False,
+			wantPulled: 4,
+		},
 	}
 
 	for _, c := range cases {
@@ -115,6 +157,9 @@ func TestSeqEmpty(t *testing.T) {
 	if KnownSeq(empty) != Known[Trit]() || KnownSeq(empty) != True {
 		t.Errorf("KnownSeq(empty) must equal Known() = True")
 	}
+	if CoalesceSeq(empty) != Coalesce[Trit]() || CoalesceSeq(empty) != Unknown {
+		t.Errorf("CoalesceSeq(empty) must equal Coalesce() = Unknown")
+	}
 }
 
 // TestSeqParityWithVariadic asserts the Seq forms agree with their variadic
@@ -151,6 +196,9 @@ func TestSeqParityWithVariadic(t *testing.T) {
 		if KnownSeq(full) != Known(in...) {
 			t.Errorf("KnownSeq != Known for %v", in)
 		}
+		if CoalesceSeq(full) != Coalesce(in...) {
+			t.Errorf("CoalesceSeq != Coalesce for %v", in)
+		}
 	}
 }
 
@@ -0,0 +1,11 @@
+package trit
+
+// Slice is a list of Trit values with column-oriented encodings: the
+// PostgreSQL boolean[] formats (see Slice.Value and Slice.Scan) among them.
+// It converts freely to and from []Trit.
+type Slice []Trit
+
+// Matrix is a two-dimensional array of Trit values, the counterpart of a
+// two-dimensional PostgreSQL boolean[][]. Its rows must all have the same
+// length to be written to the database.
+type Matrix []Slice
@@ -0,0 +1,73 @@
+package trit
+
+import "database/sql/driver"
+
+// Trit itself maps Unknown/False/True to NULL/false/true, for nullable
+// boolean columns. The wrapper types below store the three states in other
+// column types. Each one scans every representation Trit.Scan accepts, so a
+// column can be migrated from one representation to another.
+
+// SQLInt stores a Trit in an integer column, such as SMALLINT: False,
+// Unknown and True are written as -1, 0 and 1. Unknown is 0, not NULL, so
+// the column can be NOT NULL.
+//
+// Example usage:
+//
+//	db.Exec("UPDATE flags SET state = $1", trit.SQLInt(v))
+//
+//	var state trit.SQLInt
+//	db.QueryRow("SELECT state FROM flags").Scan(&state)
+//	v := state.Trit()
+type SQLInt Trit
+
+// Value implements the driver.Valuer interface.
+func (s SQLInt) Value() (driver.Value, error) {
+	return int64(Trit(s).Val()), nil
+}
+
+// Scan implements the sql.Scanner interface, as Trit.Scan.
+func (s *SQLInt) Scan(src any) error {
+	return (*Trit)(s).Scan(src)
+}
+
+// Trit returns the stored value, so SQLInt implements Ternary.
+func (s SQLInt) Trit() Trit {
+	return Trit(s).Val()
+}
+
+// String returns the name of the stored value.
+func (s SQLInt) String() string {
+	return Trit(s).String()
+}
+
+// SQLChar stores a Trit in a one-character text column, such as CHAR(1):
+// False, Unknown and True are written as "f", "u" and "t". Unknown is "u",
+// not NULL, so the column can be NOT NULL.
+type SQLChar Trit
+
+// Value implements the driver.Valuer interface.
+func (s SQLChar) Value() (driver.Value, error) {
+	switch Trit(s).Val() {
+	case True:
+		return "t", nil
+	case False:
+		return "f", nil
+	}
+
+	return "u", nil
+}
+
+// Scan implements the sql.Scanner interface, as Trit.Scan.
+func (s *SQLChar) Scan(src any) error {
+	return (*Trit)(s).Scan(src)
+}
+
+// Trit returns the stored value, so SQLChar implements Ternary.
+func (s SQLChar) Trit() Trit {
+	return Trit(s).Val()
+}
+
+// String returns the name of the stored value.
+func (s SQLChar) String() string {
+	return Trit(s).String()
+}
@@ -0,0 +1,92 @@
+package trit
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+// TestScanDriverKinds covers the driver-specific source types: every
+// integer width, float32, raw BIT(1) bytes and sql.RawBytes.
+func TestScanDriverKinds(t *testing.T) {
+	cases := []struct {
+		src  any
+		want Trit
+	}{
+		{int(-3), False},
+		{int8(1), True},
+		{int16(0), Unknown},
+		{int32(-1), False},
+		{uint(2), True},
+		{uint8(1), True},
+		{uint8(0), Unknown},
+		{uint16(9), True},
+		{uint32(0), Unknown},
+		{uint64(1 << 63), True},
+		{float32(-0.5), False},
+		{[]byte{0x00}, False},
+		{[]byte{0x01}, True},
+		{[]byte("1"), True}, // text, not a raw bit
+		{[]byte("0"), Unknown},
+		{[]byte("t"), True},
+		{[]byte("f"), False},
+		{sql.RawBytes("t"), True},
+		{sql.RawBytes{0x00}, False},
+		{"u", Unknown},
+	}
+	for _, c := range cases {
+		var v Trit
+		if err := v.Scan(c.src); err != nil {
+			t.Errorf("Scan(%#v) error: %v", c.src, err)
+			continue
+		}
+		if v != c.want {
+			t.Errorf("Scan(%#v) = %s, want %s", c.src, v, c.want)
+		}
+	}
+
+	var v Trit
+	if err := v.Scan([]byte{0x02}); err == nil {
+		t.Errorf("Scan(0x02) must fail: only 0x00 and 0x01 are raw bits")
+	}
+}
+
+// TestSQLWrappers checks the values written by each wrapper and the
+// round-trip through its own Scan.
+func TestSQLWrappers(t *testing.T) {
+	ints := map[Trit]driver.Value{False: int64(-1), Unknown: int64(0), True: int64(1)}
+	chars := map[Trit]driver.Value{False: "f", Unknown: "u", True: "t"}
+
+	for _, v := range canonical {
+		dv, err := SQLInt(v).Value()
+		if err != nil || dv != ints[v] {
+			t.Errorf("SQLInt(%s).Value() = %#v, %v", v, dv, err)
+		}
+		var si SQLInt
+		if err := si.Scan(dv); err != nil || si.Trit() != v {
+			t.Errorf("SQLInt round-trip %s -> %#v -> %s (%v)", v, dv, si, err)
+		}
+
+		dv, err = SQLChar(v).Value()
+		if err != nil || dv != chars[v] {
+			t.Errorf("SQLChar(%s).Value() = %#v, %v", v, dv, err)
+		}
+		var sc SQLChar
+		if err := sc.Scan(dv); err != nil || sc.Trit() != v {
+			t.Errorf("SQLChar round-trip %s -> %#v -> %s (%v)", v, dv, sc, err)
+		}
+	}
+
+	if dv, _ := SQLInt(Trit(7)).Value(); dv != int64(1) {
+		t.Errorf("SQLInt must normalize, got %#v", dv)
+	}
+	if SQLChar(Trit(-4)).String() != "False" || AllOf(SQLInt(True), SQLInt(1)) != True {
+		t.Errorf("wrappers must behave as Trit")
+	}
+
+	// Migrating representations: a wrapper scans what another one wrote.
+	var si SQLInt
+	if err := si.Scan("t"); err != nil || si.Trit() != True {
+		t.Errorf("SQLInt.Scan(\"t\") = %s, %v", si, err)
+	}
+}
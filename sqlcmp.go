@@ -0,0 +1,251 @@
+package trit
+
+import (
+	"cmp"
+	"unicode"
+)
+
+// This file reproduces SQL's three-valued comparisons for in-memory query
+// code. A nil pointer stands for SQL NULL, and every comparison that
+// involves NULL yields Unknown unless SQL defines otherwise (IS DISTINCT
+// FROM, the short-circuits of IN and BETWEEN). Floating-point NaN follows
+// PostgreSQL: NaN equals NaN and sorts above every other value.
+
+// Equal is SQL's a = b: Unknown if either value is NULL (nil), otherwise
+// True or False.
+//
+// Example usage:
+//
+//	age := 30
+//	fmt.Println(trit.Equal(&age, nil)) // Output: Unknown
+func Equal[T comparable](a, b *T) Trit {
+	if a == nil || b == nil {
+		return Unknown
+	}
+
+	return Define(sqlEqual(*a, *b))
+}
+
+// NotEqual is SQL's a <> b: Unknown if either value is NULL (nil), otherwise
+// True or False.
+func NotEqual[T comparable](a, b *T) Trit {
+	return Equal(a, b).Not()
+}
+
+// Less is SQL's a < b: Unknown if either value is NULL (nil).
+func Less[T cmp.Ordered](a, b *T) Trit {
+	return sqlOrder(a, b, func(c int) bool { return c < 0 })
+}
+
+// LessEq is SQL's a <= b: Unknown if either value is NULL (nil).
+func LessEq[T cmp.Ordered](a, b *T) Trit {
+	return sqlOrder(a, b, func(c int) bool { return c <= 0 })
+}
+
+// Greater is SQL's a > b: Unknown if either value is NULL (nil).
+func Greater[T cmp.Ordered](a, b *T) Trit {
+	return sqlOrder(a, b, func(c int) bool { return c > 0 })
+}
+
+// GreaterEq is SQL's a >= b: Unknown if either value is NULL (nil).
+func GreaterEq[T cmp.Ordered](a, b *T) Trit {
+	return sqlOrder(a, b, func(c int) bool { return c >= 0 })
+}
+
+// IsDistinctFrom is SQL's a IS DISTINCT FROM b, the NULL-safe inequality.
+// It is never Unknown: two NULLs are not distinct, a NULL and a value are.
+func IsDistinctFrom[T comparable](a, b *T) Trit {
+	switch {
+	case a == nil && b == nil:
+		return False
+	case a == nil || b == nil:
+		return True
+	}
+
+	return Define(!sqlEqual(*a, *b))
+}
+
+// IsNotDistinctFrom is SQL's a IS NOT DISTINCT FROM b, the NULL-safe
+// equality. It is never Unknown.
+func IsNotDistinctFrom[T comparable](a, b *T) Trit {
+	return IsDistinctFrom(a, b).Not()
+}
+
+// InList is SQL's x IN (list...). It returns True if x equals an element,
+// otherwise Unknown if x or any element is NULL, and False if x matches no
+// element. An empty list yields False, even for a NULL x, as
+// x = ANY('{}') does in PostgreSQL.
+func InList[T comparable](x *T, list []*T) Trit {
+	if len(list) == 0 {
+		return False
+	}
+
+	if x == nil {
+		return Unknown
+	}
+
+	result := False
+	for _, v := range list {
+		if v == nil {
+			result = Unknown
+		} else if sqlEqual(*x, *v) {
+			return True
+		}
+	}
+
+	return result
+}
+
+// NotInList is SQL's x NOT IN (list...), the negation of InList. It
+// reproduces the well-known trap: once the list contains a NULL, NOT IN can
+// never be True, because x <> NULL is Unknown.
+//
+// Example usage:
+//
+//	one, two := 1, 2
+//	fmt.Println(trit.NotInList(&one, []*int{&two}))      // True
+//	fmt.Println(trit.NotInList(&one, []*int{&two, nil})) // Unknown
+func NotInList[T comparable](x *T, list []*T) Trit {
+	return InList(x, list).Not()
+}
+
+// Between is SQL's x BETWEEN lo AND hi, that is x >= lo AND x <= hi under
+// Kleene conjunction. A NULL bound does not always give Unknown: 10 BETWEEN
+// NULL AND 5 is False. As in SQL, the bounds are not swapped, so lo > hi
+// never matches.
+func Between[T cmp.Ordered](x, lo, hi *T) Trit {
+	return GreaterEq(x, lo).And(LessEq(x, hi))
+}
+
+// NotBetween is SQL's x NOT BETWEEN lo AND hi, the negation of Between.
+func NotBetween[T cmp.Ordered](x, lo, hi *T) Trit {
+	return Between(x, lo, hi).Not()
+}
+
+// Like is SQL's s LIKE pattern: Unknown if either is NULL (nil), otherwise
+// whether the whole of s matches pattern. In the pattern, % matches any run
+// of characters (including none), _ matches exactly one character, and a
+// backslash makes the next character literal. A trailing backslash, which
+// PostgreSQL rejects, matches a literal backslash.
+//
+// Example usage:
+//
+//	s, p := "trit_v2", `trit\_%`
+//	fmt.Println(trit.Like(&s, &p)) // Output: True
+func Like(s, pattern *string) Trit {
+	return sqlLike(s, pattern, false)
+}
+
+// ILike is PostgreSQL's s ILIKE pattern, the case-insensitive form of Like.
+func ILike(s, pattern *string) Trit {
+	return sqlLike(s, pattern, true)
+}
+
+// sqlEqual compares like SQL: equal values are equal and, unlike Go's ==,
+// so are two NaNs. Only NaN is unequal to itself among comparable values.
+func sqlEqual[T comparable](a, b T) bool {
+	return a == b || (a != a && b != b)
+}
+
+// sqlCompare orders like PostgreSQL, which places NaN above every other
+// value, where cmp.Compare places it below.
+func sqlCompare[T cmp.Ordered](a, b T) int {
+	aNaN, bNaN := a != a, b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return 1
+	case bNaN:
+		return -1
+	}
+
+	return cmp.Compare(a, b)
+}
+
+// sqlOrder applies test to the comparison of a and b, or returns Unknown if
+// either is NULL.
+func sqlOrder[T cmp.Ordered](a, b *T, test func(int) bool) Trit {
+	if a == nil || b == nil {
+		return Unknown
+	}
+
+	return Define(test(sqlCompare(*a, *b)))
+}
+
+// likeToken is one element of a parsed LIKE pattern.
+type likeToken struct {
+	kind byte // 'c' literal character, '_' any character, '%' any run
+	r    rune
+}
+
+// sqlLike implements Like and ILike.
+func sqlLike(s, pattern *string, fold bool) Trit {
+	if s == nil || pattern == nil {
+		return Unknown
+	}
+
+	var tokens []likeToken
+	p := []rune(*pattern)
+	for i := 0; i < len(p); i++ {
+		switch r := p[i]; {
+		case r == '\\' && i+1 < len(p):
+			i++
+			tokens = append(tokens, likeToken{'c', p[i]})
+		case r == '%' || r == '_':
+			tokens = append(tokens, likeToken{kind: byte(r)})
+		default:
+			tokens = append(tokens, likeToken{'c', r})
+		}
+	}
+
+	return Define(likeMatch([]rune(*s), tokens, fold))
+}
+
+// likeMatch reports whether tokens match all of s. It is the usual greedy
+// wildcard matcher: on a mismatch it backtracks to the most recent %, which
+// then absorbs one more character, so it runs in O(len(s)·len(tokens)).
+func likeMatch(s []rune, tokens []likeToken, fold bool) bool {
+	si, ti := 0, 0
+	star, mark := -1, 0
+	for si < len(s) {
+		switch {
+		case ti < len(tokens) && tokens[ti].kind == '%':
+			star, mark = ti, si
+			ti++
+		case ti < len(tokens) && (tokens[ti].kind == '_' ||
+			sameRune(tokens[ti].r, s[si], fold)):
+			si++
+			ti++
+		case star >= 0:
+			mark++
+			si, ti = mark, star+1
+		default:
+			return false
+		}
+	}
+
+	for ti < len(tokens) && tokens[ti].kind == '%' {
+		ti++
+	}
+
+	return ti == len(tokens)
+}
+
+// sameRune compares two characters, ignoring case if fold is set.
+func sameRune(a, b rune, fold bool) bool {
+	if a == b {
+		return true
+	}
+	if !fold {
+		return false
+	}
+
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package trit
+
+import (
+	"math"
+	"testing"
+)
+
+// ptr returns a pointer to v, the non-NULL form of a nullable value.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// TestSQLComparisons follows the comparison operator tables of the
+// PostgreSQL documentation (Comparison Functions and Operators): any
+// ordinary comparison with NULL yields NULL, IS DISTINCT FROM never does.
+func TestSQLComparisons(t *testing.T) {
+	one, two := ptr(1), ptr(2)
+	var null *int
+
+	cases := []struct {
+		name string
+		got  Trit
+		want Trit
+	}{
+		{"1 = 1", Equal(one, ptr(1)), True},
+		{"1 = 2", Equal(one, two), False},
+		{"1 = NULL", Equal(one, null), Unknown},
+		{"NULL = NULL", Equal(null, null), Unknown},
+		{"1 <> 2", NotEqual(one, two), True},
+		{"1 <> NULL", NotEqual(one, null), Unknown},
+		{"1 < 2", Less(one, two), True},
+		{"2 < 1", Less(two, one), False},
+		{"1 < NULL", Less(one, null), Unknown},
+		{"1 <= 1", LessEq(one, one), True},
+		{"2 > 1", Greater(two, one), True},
+		{"NULL > 1", Greater(null, one), Unknown},
+		{"1 >= 2", GreaterEq(one, two), False},
+		{"1 IS DISTINCT FROM 2", IsDistinctFrom(one, two), True},
+		{"1 IS DISTINCT FROM 1", IsDistinctFrom(one, ptr(1)), False},
+		{"1 IS DISTINCT FROM NULL", IsDistinctFrom(one, null), True},
+		{"NULL IS DISTINCT FROM NULL", IsDistinctFrom(null, null), False},
+		{"NULL IS NOT DISTINCT FROM NULL", IsNotDistinctFrom(null, null), True},
+		{"1 IS NOT DISTINCT FROM NULL", IsNotDistinctFrom(one, null), False},
+		{"'a' < 'b'", Less(ptr("a"), ptr("b")), True},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s = %s, want %s", c.name, c.got, c.want)
+		}
+	}
+}
+
+// TestSQLNaN pins PostgreSQL's NaN ordering: NaN = NaN and NaN is greater
+// than every other value.
+func TestSQLNaN(t *testing.T) {
+	nan, inf, one := ptr(math.NaN()), ptr(math.Inf(1)), ptr(1.0)
+	if Equal(nan, ptr(math.NaN())) != True {
+		t.Errorf("NaN = NaN must be true")
+	}
+	if Greater(nan, inf) != True || Less(one, nan) != True {
+		t.Errorf("NaN must sort above every other value")
+	}
+	if IsDistinctFrom(nan, nan) != False {
+		t.Errorf("NaN IS DISTINCT FROM NaN must be false")
+	}
+	if InList(nan, []*float64{one, ptr(math.NaN())}) != True {
+		t.Errorf("NaN IN (1, NaN) must be true")
+	}
+}
+
+// TestSQLInList covers the IN rules, including the NOT IN + NULL trap.
+func TestSQLInList(t *testing.T) {
+	one, two, three := ptr(1), ptr(2), ptr(3)
+	var null *int
+
+	cases := []struct {
+		name      string
+		x         *int
+		list      []*int
+		in, notIn Trit
+	}{
+		{"1 IN (1, 2)", one, []*int{one, two}, True, False},
+		{"3 IN (1, 2)", three, []*int{one, two}, False, True},
+		{"1 IN (2, NULL)", one, []*int{two, null}, Unknown, Unknown},
+		{"1 IN (NULL, 1)", one, []*int{null, one}, True, False},
+		{"NULL IN (1, 2)", null, []*int{one, two}, Unknown, Unknown},
+		{"NULL IN (NULL)", null, []*int{null}, Unknown, Unknown},
+		{"1 IN ()", one, nil, False, True},
+		{"NULL IN ()", null, []*int{}, False, True},
+	}
+	for _, c := range cases {
+		if got := InList(c.x, c.list); got != c.in {
+			t.Errorf("%s = %s, want %s", c.name, got, c.in)
+		}
+		if got := NotInList(c.x, c.list); got != c.notIn {
+			t.Errorf("NOT (%s) = %s, want %s", c.name, got, c.notIn)
+		}
+	}
+}
+
+// TestSQLBetween covers BETWEEN with NULL operands and reversed bounds.
+func TestSQLBetween(t *testing.T) {
+	var null *int
+	cases := []struct {
+		name      string
+		x, lo, hi *int
+		want      Trit
+	}{
+		{"2 BETWEEN 1 AND 3", ptr(2), ptr(1), ptr(3), True},
+		{"1 BETWEEN 1 AND 1", ptr(1), ptr(1), ptr(1), True},
+		{"4 BETWEEN 1 AND 3", ptr(4), ptr(1), ptr(3), False},
+		{"2 BETWEEN 3 AND 1", ptr(2), ptr(3), ptr(1), False},
+		{"NULL BETWEEN 1 AND 3", null, ptr(1), ptr(3), Unknown},
+		{"2 BETWEEN NULL AND 3", ptr(2), null, ptr(3), Unknown},
+		{"10 BETWEEN NULL AND 5", ptr(10), null, ptr(5), False},
+		{"0 BETWEEN 1 AND NULL", ptr(0), ptr(1), null, False},
+	}
+	for _, c := range cases {
+		if got := Between(c.x, c.lo, c.hi); got != c.want {
+			t.Errorf("%s = %s, want %s", c.name, got, c.want)
+		}
+		if got := NotBetween(c.x, c.lo, c.hi); got != c.want.Not() {
+			t.Errorf("NOT (%s) = %s, want %s", c.name, got, c.want.Not())
+		}
+	}
+}
+
+// TestSQLLike uses the examples of the PostgreSQL documentation (Pattern
+// Matching, LIKE) plus escapes, NULLs and multi-byte characters.
+func TestSQLLike(t *testing.T) {
+	cases := []struct {
+		s, pattern string
+		like       Trit
+		ilike      Trit
+	}{
+		{"abc", "abc", True, True},
+		{"abc", "a%", True, True},
+		{"abc", "_b_", True, True},
+		{"abc", "c", False, False},
+		{"abc", "%", True, True},
+		{"", "%", True, True},
+		{"", "_", False, False},
+		{"abc", "%c", True, True},
+		{"abc", "%b", False, False},
+		{"aXbXc", "a%b%c", True, True},
+		{"ab", "a%b%c", False, False},
+		{"mississippi", "%iss%ppi", True, True},
+		{"100%", `100\%`, True, True},
+		{"1000", `100\%`, False, False},
+		{"a_b", `a\_b`, True, True},
+		{"axb", `a\_b`, False, False},
+		{`a\`, `a\`, True, True},
+		{"ABC", "abc", False, True},
+		{"Ünïcödé", "ü_ï%É", False, True},
+		{"日本語", "日_語", True, True},
+	}
+	for _, c := range cases {
+		s, p := c.s, c.pattern
+		if got := Like(&s, &p); got != c.like {
+			t.Errorf("%q LIKE %q = %s, want %s", s, p, got, c.like)
+		}
+		if got := ILike(&s, &p); got != c.ilike {
+			t.Errorf("%q ILIKE %q = %s, want %s", s, p, got, c.ilike)
+		}
+	}
+
+	s := "abc"
+	if Like(nil, &s) != Unknown || Like(&s, nil) != Unknown {
+		t.Errorf("LIKE with NULL must be Unknown")
+	}
+}
@@ -0,0 +1,113 @@
+package trit
+
+// Tally counts True, False and Unknown votes as they arrive, so Majority and
+// Consensus can be computed over unbounded or distributed inputs without
+// holding the whole slice. Tallies are mergeable: shard the counting across
+// goroutines, give each its own Tally, and combine them with Merge.
+//
+// The zero value is an empty Tally ready to use. A Tally is a plain value;
+// like a Trit, a single Tally mutated from several goroutines needs external
+// synchronization.
+//
+// Example usage:
+//
+//	var votes trit.Tally
+//	for v := range ballots {
+//		votes.Add(v)
+//	}
+//	fmt.Println(votes.Majority())
+type Tally struct {
+	trues, falses, unknowns int
+}
+
+// Add counts one vote. Non-canonical values count by their normalized state.
+func (c *Tally) Add(v Trit) {
+	c.AddN(v, 1)
+}
+
+// AddN counts n identical votes. A non-positive n is a no-op.
+func (c *Tally) AddN(v Trit, n int) {
+	if n <= 0 {
+		return
+	}
+
+	switch v.Val() {
+	case True:
+		c.trues += n
+	case False:
+		c.falses += n
+	default:
+		c.unknowns += n
+	}
+}
+
+// Merge adds the votes counted by o, as if every vote of o had been added to
+// c. Merging is associative and commutative.
+func (c *Tally) Merge(o Tally) {
+	c.trues += o.trues
+	c.falses += o.falses
+	c.unknowns += o.unknowns
+}
+
+// Counts returns the number of True, False and Unknown votes.
+func (c Tally) Counts() (trues, falses, unknowns int) {
+	return c.trues, c.falses, c.unknowns
+}
+
+// Len returns the total number of votes.
+func (c Tally) Len() int {
+	return c.trues + c.falses + c.unknowns
+}
+
+// Majority returns True if more than half of the votes are True, False if
+// more than half are False, and Unknown otherwise. Unknown votes count
+// toward the total, so they can prevent a majority. It always agrees with
+// the Majority function over the same votes.
+func (c Tally) Majority() Trit {
+	switch n := c.Len(); {
+	case c.trues > n/2:
+		return True
+	case c.falses > n/2:
+		return False
+	}
+
+	return Unknown
+}
+
+// Consensus returns True if every vote is True, False if every vote is
+// False, and Unknown otherwise, including for an empty tally. It always
+// agrees with the Consensus function over the same votes, but unlike the
+// function the counts remain available after an Unknown vote.
+func (c Tally) Consensus() Trit {
+	switch n := c.Len(); {
+	case n == 0:
+		return Unknown
+	case c.trues == n:
+		return True
+	case c.falses == n:
+		return False
+	}
+
+	return Unknown
+}
+
+// Plurality returns the state with strictly more votes than each of the
+// other two. A tie for the most votes, or an empty tally, yields Unknown.
+//
+// Example usage:
+//
+//	var c trit.Tally
+//	c.AddN(trit.True, 4)
+//	c.AddN(trit.False, 3)
+//	c.AddN(trit.Unknown, 3)
+//	fmt.Println(c.Plurality(), c.Majority()) // Output: True Unknown
+func (c Tally) Plurality() Trit {
+	switch {
+	case c.trues > c.falses && c.trues > c.unknowns:
+		return True
+	case c.falses > c.trues && c.falses > c.unknowns:
+		return False
+	}
+
+	return Unknown
+}
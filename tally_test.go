@@ -0,0 +1,105 @@
+package trit
+
+import "testing"
+
+// allInputs returns every sequence of canonical states up to length n.
+func allInputs(n int) [][]Trit {
+	out := [][]Trit{{}}
+	prev := [][]Trit{{}}
+	for range n {
+		var next [][]Trit
+		for _, p := range prev {
+			for _, v := range canonical {
+				next = append(next, append(append([]Trit(nil), p...), v))
+			}
+		}
+		out = append(out, next...)
+		prev = next
+	}
+
+	return out
+}
+
+// TestTallyMatchesFunctions asserts exhaustively that a Tally always agrees
+// with the Majority and Consensus functions over the same votes.
+func TestTallyMatchesFunctions(t *testing.T) {
+	for _, in := range allInputs(5) {
+		var c Tally
+		for _, v := range in {
+			c.Add(v)
+		}
+
+		if c.Len() != len(in) {
+			t.Errorf("Len(%v) = %d", in, c.Len())
+		}
+		if got, want := c.Majority(), Majority(in...); got != want {
+			t.Errorf("Tally.Majority(%v) = %s, Majority = %s", in, got, want)
+		}
+		if got, want := c.Consensus(), Consensus(in...); got != want {
+			t.Errorf("Tally.Consensus(%v) = %s, Consensus = %s", in, got, want)
+		}
+	}
+}
+
+// TestTallyMerge checks that merging shards equals counting everything in
+// one tally, and that AddN equals repeated Add.
+func TestTallyMerge(t *testing.T) {
+	votes := []Trit{True, False, Unknown, True, Trit(4), Trit(-4), True}
+
+	var whole Tally
+	for _, v := range votes {
+		whole.Add(v)
+	}
+
+	var a, b Tally
+	for i, v := range votes {
+		if i%2 == 0 {
+			a.Add(v)
+		} else {
+			b.Add(v)
+		}
+	}
+	a.Merge(b)
+	if a != whole {
+		t.Errorf("merged %+v != whole %+v", a, whole)
+	}
+
+	trues, falses, unknowns := whole.Counts()
+	if trues != 4 || falses != 2 || unknowns != 1 {
+		t.Errorf("Counts = %d %d %d, want 4 2 1", trues, falses, unknowns)
+	}
+
+	var n Tally
+	n.AddN(True, 4)
+	n.AddN(False, 2)
+	n.AddN(Unknown, 1)
+	n.AddN(False, 0)
+	n.AddN(False, -3)
+	if n != whole {
+		t.Errorf("AddN tally %+v != %+v", n, whole)
+	}
+}
+
+// TestTallyPlurality covers clear winners, ties and the empty tally.
+func TestTallyPlurality(t *testing.T) {
+	cases := []struct {
+		t, f, u int
+		want    Trit
+	}{
+		{0, 0, 0, Unknown},
+		{4, 3, 3, True},
+		{1, 2, 0, False},
+		{2, 2, 1, Unknown}, // tie between True and False
+		{1, 1, 5, Unknown}, // Unknown leads
+		{3, 1, 3, Unknown}, // tie with Unknown
+	}
+	for _, c := range cases {
+		var v Tally
+		v.AddN(True, c.t)
+		v.AddN(False, c.f)
+		v.AddN(Unknown, c.u)
+		if got := v.Plurality(); got != c.want {
+			t.Errorf("Plurality(%d,%d,%d) = %s, want %s", c.t, c.f, c.u, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package trit
+
+import (
+	"iter"
+	"slices"
+)
+
+// Ternary is implemented by any type that can report its state as a Trit.
+// Unlike the closed Logicable union it is open, so domain types can take
+// part in the aggregates through the …Of functions:
+//
+//	type HealthStatus struct{ Healthy, Checked bool }
+//
+//	func (h HealthStatus) Trit() trit.Trit {
+//		if !h.Checked {
+//			return trit.Unknown
+//		}
+//		return trit.Define(h.Healthy)
+//	}
+//
+//	ready := trit.AllOf(statuses...)
+//
+// Trit itself implements Ternary. A type that implements Tritter instead can
+// be adapted with FromTritter.
+type Ternary interface {
+	Trit() Trit
+}
+
+// Trit returns the normalized value of t, so that Trit implements Ternary.
+func (t Trit) Trit() Trit {
+	return t.Val()
+}
+
+// FromTritter returns the state reported by t: True if t.IsTrue, False if
+// t.IsFalse, and Unknown otherwise, including for a nil t or a nil *Trit.
+// If t also implements Ternary, its Trit method is used instead.
+func FromTritter(t Tritter) Trit {
+	switch v := t.(type) {
+	case nil:
+		return Unknown
+	case *Trit:
+		if v == nil {
+			return Unknown
+		}
+		return v.Val()
+	case Ternary:
+		return v.Trit().Val()
+	}
+
+	switch {
+	case t.IsTrue():
+		return True
+	case t.IsFalse():
+		return False
+	}
+
+	return Unknown
+}
+
+// Of converts values of a Ternary type to a slice of Trit, for use with the
+// aggregates that have no …Of form:
+//
+//	trit.ImpChain(trit.Of(stages...)...)
+func Of[T Ternary](vs ...T) []Trit {
+	result := make([]Trit, len(vs))
+	for i, v := range vs {
+		result[i] = v.Trit().Val()
+	}
+
+	return result
+}
+
+// OfSeq converts a sequence of a Ternary type to a sequence of Trit, for use
+// with the …Seq aggregates.
+func OfSeq[T Ternary](seq iter.Seq[T]) iter.Seq[Trit] {
+	return func(yield func(Trit) bool) {
+		for v := range seq {
+			if !yield(v.Trit().Val()) {
+				return
+			}
+		}
+	}
+}
+
+// AllOf is All for a Ternary type: True if every value is True, and False
+// as soon as any value is False or Unknown.
+func AllOf[T Ternary](vs ...T) Trit {
+	return AllSeq(OfSeq(slices.Values(vs)))
+}
+
+// AnyOf is Any for a Ternary type: True as soon as any value is True, and
+// False otherwise.
+func AnyOf[T Ternary](vs ...T) Trit {
+	return AnySeq(OfSeq(slices.Values(vs)))
+}
+
+// NoneOf is None for a Ternary type: True if no value is True.
+func NoneOf[T Ternary](vs ...T) Trit {
+	return NoneSeq(OfSeq(slices.Values(vs)))
+}
+
+// KnownOf is Known for a Ternary type: True if no value is Unknown.
+func KnownOf[T Ternary](vs ...T) Trit {
+	return KnownSeq(OfSeq(slices.Values(vs)))
+}
+
+// AndOf is AndAll for a Ternary type: the Kleene conjunction of the values.
+func AndOf[T Ternary](vs ...T) Trit {
+	return AndAllSeq(OfSeq(slices.Values(vs)))
+}
+
+// OrOf is OrAll for a Ternary type: the Kleene disjunction of the values.
+func OrOf[T Ternary](vs ...T) Trit {
+	return OrAllSeq(OfSeq(slices.Values(vs)))
+}
+
+// ConsensusOf is Consensus for a Ternary type: True if every value is True,
+// False if every value is False, and Unknown otherwise.
+func ConsensusOf[T Ternary](vs ...T) Trit {
+	return tallyOf(Of(vs...)).Consensus()
+}
+
+// MajorityOf is Majority for a Ternary type: True if more than half of the
+// values are True, False if more than half are False, and Unknown otherwise.
+func MajorityOf[T Ternary](vs ...T) Trit {
+	return tallyOf(Of(vs...)).Majority()
+}
@@ -0,0 +1,98 @@
+package trit
+
+import (
+	"slices"
+	"testing"
+)
+
+// healthStatus is a domain type that reports its state through Ternary.
+type healthStatus struct {
+	checked, healthy bool
+}
+
+func (h healthStatus) Trit() Trit {
+	if !h.checked {
+		return Unknown
+	}
+	return Define(h.healthy)
+}
+
+// legacyStatus implements only Tritter.
+type legacyStatus int
+
+func (s legacyStatus) IsTrue() bool    { return s > 0 }
+func (s legacyStatus) IsFalse() bool   { return s < 0 }
+func (s legacyStatus) IsUnknown() bool { return s == 0 }
+func (s legacyStatus) Int() int        { return int(s) }
+func (s legacyStatus) String() string  { return Define(int(s)).String() }
+
+// TestOfAggregates checks every …Of function against its Logicable
+// counterpart over all short inputs.
+func TestOfAggregates(t *testing.T) {
+	toStatus := map[Trit]healthStatus{
+		True:    {checked: true, healthy: true},
+		False:   {checked: true},
+		Unknown: {},
+	}
+
+	for _, in := range allInputs(4) {
+		hs := make([]healthStatus, len(in))
+		for i, v := range in {
+			hs[i] = toStatus[v]
+		}
+
+		if got := Of(hs...); !slices.Equal(got, in) {
+			t.Errorf("Of(%v) = %v", in, got)
+		}
+
+		checks := []struct {
+			name      string
+			got, want Trit
+		}{
+			{"AllOf", AllOf(hs...), All(in...)},
+			{"AnyOf", AnyOf(hs...), Any(in...)},
+			{"NoneOf", NoneOf(hs...), None(in...)},
+			{"KnownOf", KnownOf(hs...), Known(in...)},
+			{"AndOf", AndOf(hs...), AndAll(in...)},
+			{"OrOf", OrOf(hs...), OrAll(in...)},
+			{"ConsensusOf", ConsensusOf(hs...), Consensus(in...)},
+			{"MajorityOf", MajorityOf(hs...), Majority(in...)},
+		}
+		for _, c := range checks {
+			if c.got != c.want {
+				t.Errorf("%s(%v) = %s, want %s", c.name, in, c.got, c.want)
+			}
+		}
+	}
+
+	if got := AndOf(True, Trit(5), Unknown); got != Unknown {
+		t.Errorf("AndOf over Trit = %s, want Unknown", got)
+	}
+}
+
+// TestFromTritter covers Tritter-only types, Ternary types and nil.
+func TestFromTritter(t *testing.T) {
+	cases := []struct {
+		in   Tritter
+		want Trit
+	}{
+		{legacyStatus(7), True},
+		{legacyStatus(-2), False},
+		{legacyStatus(0), Unknown},
+		{nil, Unknown},
+		{(*Trit)(nil), Unknown},
+	}
+	for _, c := range cases {
+		if got := FromTritter(c.in); got != c.want {
+			t.Errorf("FromTritter(%v) = %s, want %s", c.in, got, c.want)
+		}
+	}
+
+	v := Trit(-9)
+	if got := FromTritter(&v); got != False {
+		t.Errorf("FromTritter(*Trit) = %s, want False", got)
+	}
+	if got := Trit(4).Trit(); got != True {
+		t.Errorf("Trit(4).Trit() = %s, want True", got)
+	}
+}
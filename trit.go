@@ -1,10 +1,12 @@
 package trit
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -694,6 +696,113 @@ func (t *Trit) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("%w: %s", ErrInvalidTrit, s)
 }
 
+// CBOR simple values used by MarshalCBOR/UnmarshalCBOR (RFC 8949, 3.3).
+const (
+	cborFalse     = 0xf4
+	cborTrue      = 0xf5
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+)
+
+// MarshalCBOR implements the cbor.Marshaler interface (as defined by
+// github.com/fxamacker/cbor and compatible libraries). True and False are
+// encoded as the CBOR booleans; Unknown is encoded as the simple value
+// undefined (23) rather than null, because the state is not absent but
+// indeterminate.
+func (t Trit) MarshalCBOR() ([]byte, error) {
+	switch t.Val() {
+	case False:
+		return []byte{cborFalse}, nil
+	case True:
+		return []byte{cborTrue}, nil
+	}
+
+	return []byte{cborUndefined}, nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. The input must be
+// exactly one CBOR data item of one of the following shapes:
+//   - undefined / null  -> Unknown
+//   - true / false      -> True / False
+//   - an integer/float  -> sign-based: positive -> True, negative -> False,
+//     zero and NaN -> Unknown (mirrors UnmarshalJSON)
+func (t *Trit) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty cbor data", ErrInvalidTrit)
+	}
+
+	head, info, arg := data[0]>>5, data[0]&0x1f, data[1:]
+	size := 0
+	switch {
+	case info < 24:
+		// The argument is encoded in the initial byte itself.
+	case info >= 24 && info <= 27:
+		size = 1 << (info - 24)
+	default:
+		return fmt.Errorf("%w: cbor item 0x%02x", ErrInvalidTrit, data[0])
+	}
+
+	if len(arg) != size {
+		return fmt.Errorf("%w: malformed cbor item", ErrInvalidTrit)
+	}
+
+	var n uint64
+	if size == 0 {
+		n = uint64(info)
+	} else {
+		for _, b := range arg {
+			n = n<<8 | uint64(b)
+		}
+	}
+
+	switch head {
+	case 0: // unsigned integer
+		*t = fromUint(n)
+		return nil
+	case 1: // negative integer, -1-n
+		*t = False
+		return nil
+	case 7: // simple values and floats
+		switch {
+		case size == 0 && data[0] == cborFalse:
+			*t = False
+			return nil
+		case size == 0 && data[0] == cborTrue:
+			*t = True
+			return nil
+		case size == 0 && (data[0] == cborNull || data[0] == cborUndefined):
+			*t = Unknown
+			return nil
+		case size == 2:
+			*t = fromHalf(uint16(n))
+			return nil
+		case size == 4:
+			*t = fromFloat(float64(math.Float32frombits(uint32(n))))
+			return nil
+		case size == 8:
+			*t = fromFloat(math.Float64frombits(n))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: cbor item 0x%02x", ErrInvalidTrit, data[0])
+}
+
+// fromHalf maps an IEEE 754 half-precision float, given as raw bits, onto a
+// Trit by its sign. Only the sign, zero and NaN cases matter here, so the
+// value does not need to be widened to a float64.
+func fromHalf(bits uint16) Trit {
+	exp, frac := bits>>10&0x1f, bits&0x3ff
+	switch {
+	case exp == 0 && frac == 0, exp == 0x1f && frac != 0:
+		return Unknown // signed zero or NaN
+	case bits&0x8000 != 0:
+		return False
+	default:
+		return True
+	}
+}
+
 // MarshalText implements the encoding.TextMarshaler interface, producing
 // "True", "False" or "Unknown". This enables Trit values to be used with
 // text-based encoders (flags, YAML, TOML, CSV, map keys, etc.).
@@ -772,8 +881,12 @@ func (t Trit) Value() (driver.Value, error) {
 // driver value kinds and maps them onto a Trit:
 //   - nil            -> Unknown (SQL NULL)
 //   - bool           -> True / False
-//   - integer/float  -> sign-based (see Set)
-//   - string/[]byte  -> parsed via ParseTrit
+//   - integer/float  -> sign-based (see Set), for every integer width and
+//     float32 as some drivers return them
+//   - single byte    -> a raw bit, as MySQL returns BIT(1): 0x00 is False
+//     and 0x01 is True
+//   - string/[]byte  -> parsed via ParseTrit, which also accepts the "t"/"f"
+//     of PostgreSQL and SQLite text booleans; sql.RawBytes is read as []byte
 //
 // Unrecognized source types return ErrInvalidTrit.
 func (t *Trit) Scan(src any) error {
@@ -787,15 +900,33 @@ func (t *Trit) Scan(src any) error {
 			*t = False
 		}
 	case int64:
-		t.Set(int(v))
+		*t = fromInt(v)
+	case int:
+		*t = fromInt(int64(v))
+	case int8:
+		*t = fromInt(int64(v))
+	case int16:
+		*t = fromInt(int64(v))
+	case int32:
+		*t = fromInt(int64(v))
+	case uint:
+		*t = fromUint(uint64(v))
+	case uint8:
+		*t = fromUint(uint64(v))
+	case uint16:
+		*t = fromUint(uint64(v))
+	case uint32:
+		*t = fromUint(uint64(v))
+	case uint64:
+		*t = fromUint(v)
 	case float64:
 		*t = fromFloat(v)
+	case float32:
+		*t = fromFloat(float64(v))
+	case sql.RawBytes:
+		return t.scanBytes(v)
 	case []byte:
-		parsed, err := ParseTrit(string(v))
-		if err != nil {
-			return err
-		}
-		*t = parsed
+		return t.scanBytes(v)
 	case string:
 		parsed, err := ParseTrit(v)
 		if err != nil {
@@ -808,3 +939,22 @@ func (t *Trit) Scan(src any) error {
 
 	return nil
 }
+
+// scanBytes scans a driver byte slice: a raw bit value or text.
+func (t *Trit) scanBytes(b []byte) error {
+	if len(b) == 1 && b[0] <= 1 {
+		*t = False
+		if b[0] == 1 {
+			*t = True
+		}
+		return nil
+	}
+
+	parsed, err := ParseTrit(string(b))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+
+	return nil
+}
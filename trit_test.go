@@ -240,6 +240,77 @@ func TestUnmarshalJSONTolerant(t *testing.T) {
 	}
 }
 
+// TestCBORRoundTrip verifies the canonical CBOR mapping, in particular that
+// Unknown is written as undefined (0xf7) and not as null (0xf6).
+func TestCBORRoundTrip(t *testing.T) {
+	want := map[Trit]byte{False: 0xf4, Unknown: 0xf7, True: 0xf5}
+	for v, b := range want {
+		got, err := v.MarshalCBOR()
+		if err != nil || len(got) != 1 || got[0] != b {
+			t.Errorf("MarshalCBOR(%s) = %x, %v; want %x", v, got, err, b)
+		}
+
+		var back Trit
+		if err := back.UnmarshalCBOR(got); err != nil || back != v {
+			t.Errorf("UnmarshalCBOR(%x) = %s, %v; want %s", got, back, err, v)
+		}
+	}
+
+	// Non-canonical values are normalized before encoding.
+	if got, _ := Trit(-9).MarshalCBOR(); got[0] != 0xf4 {
+		t.Errorf("MarshalCBOR(Trit(-9)) = %x, want f4", got)
+	}
+}
+
+// TestUnmarshalCBORTolerant checks the accepted non-boolean items (null,
+// integers and floats of every width) and that malformed input is rejected.
+func TestUnmarshalCBORTolerant(t *testing.T) {
+	ok := []struct {
+		in   []byte
+		want Trit
+	}{
+		{[]byte{0xf6}, Unknown},                            // null
+		{[]byte{0x00}, Unknown},                            // 0
+		{[]byte{0x01}, True},                               // 1
+		{[]byte{0x18, 0xff}, True},                         // 255
+		{[]byte{0x19, 0x00, 0x00}, Unknown},                // 0 in two bytes
+		{[]byte{0x20}, False},                              // -1
+		{[]byte{0x38, 0x63}, False},                        // -100
+		{[]byte{0xf9, 0x3c, 0x00}, True},                   // half 1.0
+		{[]byte{0xf9, 0xbc, 0x00}, False},                  // half -1.0
+		{[]byte{0xf9, 0x80, 0x00}, Unknown},                // half -0.0
+		{[]byte{0xf9, 0x7e, 0x00}, Unknown},                // half NaN
+		{[]byte{0xf9, 0x7c, 0x00}, True},                   // half +Inf
+		{[]byte{0xfa, 0xbf, 0xc0, 0x00, 0x00}, False},      // single -1.5
+		{[]byte{0xfb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, True}, // double 1.5
+	}
+	for _, c := range ok {
+		var v Trit
+		if err := v.UnmarshalCBOR(c.in); err != nil || v != c.want {
+			t.Errorf("UnmarshalCBOR(%x) = %s, %v; want %s", c.in, v, err, c.want)
+		}
+	}
+
+	bad := [][]byte{
+		nil,                // empty
+		{0xf5, 0x00},       // trailing data
+		{0x18},             // truncated argument
+		{0x60},             // text string
+		{0x80},             // array
+		{0xc1, 0x00},       // tag
+		{0xf0},             // unassigned simple value
+		{0xf8, 0x20},       // one-byte simple value
+		{0x1c},             // reserved additional info
+		{0xfb, 0x3f, 0xf8}, // truncated double
+	}
+	for _, in := range bad {
+		var v Trit
+		if err := v.UnmarshalCBOR(in); !errors.Is(err, ErrInvalidTrit) {
+			t.Errorf("UnmarshalCBOR(%x) err = %v, want ErrInvalidTrit", in, err)
+		}
+	}
+}
+
 // TestTextMarshaling checks MarshalText/UnmarshalText and their round-trip.
 func TestTextMarshaling(t *testing.T) {
 	for _, v := range canonical {
@@ -0,0 +1,10 @@
+module github.com/goloop/trit/v2/tritpb
+
+go 1.24
+
+require (
+	github.com/goloop/trit/v2 v2.0.0
+	google.golang.org/protobuf v1.36.11
+)
+
+replace github.com/goloop/trit/v2 => ../
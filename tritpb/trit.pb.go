@@ -0,0 +1,139 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: tritpb/trit.proto
+
+package tritpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Trit is the canonical wire form of a three-valued logic digit.
+//
+// Unknown is the zero value, so an unset field decodes as Unknown exactly
+// like the zero value of trit.Trit in Go. The numbering intentionally does
+// not follow the -1/0/1 encoding of trit.Trit: proto3 enums must start at
+// zero and negative enum values are encoded inefficiently on the wire.
+type Trit int32
+
+const (
+	// TRIT_UNKNOWN is the indeterminate state and the default value.
+	Trit_TRIT_UNKNOWN Trit = 0
+	// TRIT_FALSE is the definite false state.
+	Trit_TRIT_FALSE Trit = 1
+	// TRIT_TRUE is the definite true state.
+	Trit_TRIT_TRUE Trit = 2
+)
+
+// Enum value maps for Trit.
+var (
+	Trit_name = map[int32]string{
+		0: "TRIT_UNKNOWN",
+		1: "TRIT_FALSE",
+		2: "TRIT_TRUE",
+	}
+	Trit_value = map[string]int32{
+		"TRIT_UNKNOWN": 0,
+		"TRIT_FALSE":   1,
+		"TRIT_TRUE":    2,
+	}
+)
+
+func (x Trit) Enum() *Trit {
+	p := new(Trit)
+	*p = x
+	return p
+}
+
+func (x Trit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Trit) Descriptor() protoreflect.EnumDescriptor {
+	return file_tritpb_trit_proto_enumTypes[0].Descriptor()
+}
+
+func (Trit) Type() protoreflect.EnumType {
+	return &file_tritpb_trit_proto_enumTypes[0]
+}
+
+func (x Trit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Trit.Descriptor instead.
+func (Trit) EnumDescriptor() ([]byte, []int) {
+	return file_tritpb_trit_proto_rawDescGZIP(), []int{0}
+}
+
+var File_tritpb_trit_proto protoreflect.FileDescriptor
+
+const file_tritpb_trit_proto_rawDesc = "" +
+	"\n" +
+	"\x11tritpb/trit.proto\x12\x0egoloop.trit.v2*7\n" +
+	"\x04Trit\x12\x10\n" +
+	"\fTRIT_UNKNOWN\x10\x00\x12\x0e\n" +
+	"\n" +
+	"TRIT_FALSE\x10\x01\x12\r\n" +
+	"\tTRIT_TRUE\x10\x02B\"Z github.com/goloop/trit/v2/tritpbb\x06proto3"
+
+var (
+	file_tritpb_trit_proto_rawDescOnce sync.Once
+	file_tritpb_trit_proto_rawDescData []byte
+)
+
+func file_tritpb_trit_proto_rawDescGZIP() []byte {
+	file_tritpb_trit_proto_rawDescOnce.Do(func() {
+		file_tritpb_trit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tritpb_trit_proto_rawDesc), len(file_tritpb_trit_proto_rawDesc)))
+	})
+	return file_tritpb_trit_proto_rawDescData
+}
+
+var file_tritpb_trit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_tritpb_trit_proto_goTypes = []any{
+	(Trit)(0), // 0: goloop.trit.v2.Trit
+}
+var file_tritpb_trit_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_tritpb_trit_proto_init() }
+func file_tritpb_trit_proto_init() {
+	if File_tritpb_trit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tritpb_trit_proto_rawDesc), len(file_tritpb_trit_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tritpb_trit_proto_goTypes,
+		DependencyIndexes: file_tritpb_trit_proto_depIdxs,
+		EnumInfos:         file_tritpb_trit_proto_enumTypes,
+	}.Build()
+	File_tritpb_trit_proto = out.File
+	file_tritpb_trit_proto_goTypes = nil
+	file_tritpb_trit_proto_depIdxs = nil
+}
@@ -0,0 +1,53 @@
+// Package tritpb provides the canonical Protocol Buffers representation of a
+// trit.Trit together with lossless conversions between the two.
+//
+// The wire enum is defined in trit.proto; trit.pb.go is generated from it and
+// must not be edited by hand. Services exchanging three-valued flags over gRPC
+// should import trit.proto and use the conversion helpers below at the edges,
+// so every service agrees that the zero value of the field means Unknown.
+//
+// tritpb is a separate module, github.com/goloop/trit/v2/tritpb, so that
+// only its importers depend on google.golang.org/protobuf.
+package tritpb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative ../tritpb/trit.proto
+
+import "github.com/goloop/trit/v2"
+
+// FromTrit converts a trit.Trit to its wire form. Non-canonical values are
+// normalized first, so any negative value becomes TRIT_FALSE and any
+// positive value becomes TRIT_TRUE.
+//
+// Example usage:
+//
+//	v := tritpb.FromTrit(trit.True)
+//	fmt.Println(v) // Output: TRIT_TRUE
+func FromTrit(t trit.Trit) Trit {
+	switch t.Val() {
+	case trit.False:
+		return Trit_TRIT_FALSE
+	case trit.True:
+		return Trit_TRIT_TRUE
+	}
+
+	return Trit_TRIT_UNKNOWN
+}
+
+// ToTrit converts a wire value back to a trit.Trit. Proto3 enums are open,
+// so a peer built against a newer schema may send a number this package does
+// not know about; such values decode as trit.Unknown rather than failing.
+//
+// Example usage:
+//
+//	t := tritpb.ToTrit(tritpb.Trit_TRIT_FALSE)
+//	fmt.Println(t) // Output: False
+func ToTrit(v Trit) trit.Trit {
+	switch v {
+	case Trit_TRIT_FALSE:
+		return trit.False
+	case Trit_TRIT_TRUE:
+		return trit.True
+	}
+
+	return trit.Unknown
+}
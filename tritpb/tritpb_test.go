@@ -0,0 +1,70 @@
+package tritpb
+
+import (
+	"testing"
+
+	"github.com/goloop/trit/v2"
+)
+
+// TestRoundTrip checks that every canonical state survives the trip through
+// the wire enum, and that the zero values of both types agree.
+func TestRoundTrip(t *testing.T) {
+	want := map[trit.Trit]Trit{
+		trit.False:   Trit_TRIT_FALSE,
+		trit.Unknown: Trit_TRIT_UNKNOWN,
+		trit.True:    Trit_TRIT_TRUE,
+	}
+	for v, pb := range want {
+		if got := FromTrit(v); got != pb {
+			t.Errorf("FromTrit(%s) = %s, want %s", v, got, pb)
+		}
+		if got := ToTrit(pb); got != v {
+			t.Errorf("ToTrit(%s) = %s, want %s", pb, got, v)
+		}
+	}
+
+	var zero Trit
+	if ToTrit(zero) != trit.Unknown {
+		t.Errorf("zero value must map to Unknown, got %s", ToTrit(zero))
+	}
+}
+
+// TestNonCanonical checks normalization on the way out and tolerance of
+// numbers from a newer schema on the way in.
+func TestNonCanonical(t *testing.T) {
+	if got := FromTrit(trit.Trit(-7)); got != Trit_TRIT_FALSE {
+		t.Errorf("FromTrit(-7) = %s, want TRIT_FALSE", got)
+	}
+	if got := FromTrit(trit.Trit(7)); got != Trit_TRIT_TRUE {
+		t.Errorf("FromTrit(7) = %s, want TRIT_TRUE", got)
+	}
+	for _, n := range []Trit{3, -1, 100} {
+		if got := ToTrit(n); got != trit.Unknown {
+			t.Errorf("ToTrit(%d) = %s, want Unknown", n, got)
+		}
+	}
+}
+
+// TestDescriptor pins the wire numbering; changing it would silently break
+// every peer built against the published schema.
+func TestDescriptor(t *testing.T) {
+	values := Trit(0).Descriptor().Values()
+	want := []struct {
+		name   string
+		number int32
+	}{
+		{"TRIT_UNKNOWN", 0},
+		{"TRIT_FALSE", 1},
+		{"TRIT_TRUE", 2},
+	}
+	if values.Len() != len(want) {
+		t.Fatalf("enum has %d values, want %d", values.Len(), len(want))
+	}
+	for i, w := range want {
+		v := values.Get(i)
+		if string(v.Name()) != w.name || int32(v.Number()) != w.number {
+			t.Errorf("value %d = %s(%d), want %s(%d)",
+				i, v.Name(), v.Number(), w.name, w.number)
+		}
+	}
+}
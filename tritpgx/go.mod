@@ -0,0 +1,12 @@
+module github.com/goloop/trit/v2/tritpgx
+
+go 1.24.0
+
+require (
+	github.com/goloop/trit/v2 v2.0.0
+	github.com/jackc/pgx/v5 v5.8.0
+)
+
+// trit.Slice and trit.Matrix are not in a tagged release yet; build against
+// the working tree until they are.
+replace github.com/goloop/trit/v2 => ../
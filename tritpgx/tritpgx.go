@@ -0,0 +1,206 @@
+// Package tritpgx is a pgx codec for PostgreSQL boolean[] columns holding
+// trit.Slice and trit.Matrix values, with NULL elements as trit.Unknown.
+//
+// pgx prefers the binary format for boolean[], which the sql.Scanner and
+// driver.Valuer methods of trit.Slice and trit.Matrix do not speak. Codec
+// reads and writes both formats with the binary helpers of package trit and
+// hands every other Go type to the boolean[] codec it wraps, so []bool and
+// []pgtype.Bool keep working on the same connection.
+//
+// tritpgx is a separate module, github.com/goloop/trit/v2/tritpgx, so that
+// only its importers depend on pgx.
+//
+// Example usage:
+//
+//	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		tritpgx.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+//	var results trit.Slice
+//	err := pool.QueryRow(ctx, "SELECT results FROM checks").Scan(&results)
+package tritpgx
+
+import (
+	"database/sql/driver"
+
+	"github.com/goloop/trit/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Register replaces the boolean[] type of m with a Codec wrapping the codec
+// m had for it, and makes trit.Slice and trit.Matrix default to boolean[]
+// where the server does not say which type a parameter has.
+func Register(m *pgtype.Map) {
+	var next pgtype.Codec
+	if t, ok := m.TypeForOID(pgtype.BoolArrayOID); ok {
+		next = t.Codec
+	}
+
+	m.RegisterType(&pgtype.Type{
+		Name:  "_bool",
+		OID:   pgtype.BoolArrayOID,
+		Codec: &Codec{Next: next},
+	})
+	m.RegisterDefaultPgType(trit.Slice(nil), "_bool")
+	m.RegisterDefaultPgType(trit.Matrix(nil), "_bool")
+}
+
+// Codec is a pgtype.Codec for boolean[]. It encodes trit.Slice and
+// trit.Matrix values (and pointers to them) and scans into *trit.Slice and
+// *trit.Matrix, in the binary and the text format. A nil Slice or Matrix is
+// SQL NULL, and SQL NULL scans to a nil one.
+type Codec struct {
+	// Next handles all other Go types and the generic decoding methods,
+	// usually pgx's own boolean[] codec. If Next is nil, only trit types are
+	// supported and values decode to trit.Slice.
+	Next pgtype.Codec
+}
+
+// FormatSupported implements the pgtype.Codec interface.
+func (c *Codec) FormatSupported(format int16) bool {
+	return format == pgtype.BinaryFormatCode || format == pgtype.TextFormatCode
+}
+
+// PreferredFormat implements the pgtype.Codec interface.
+func (c *Codec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+// PlanEncode implements the pgtype.Codec interface.
+func (c *Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case trit.Slice, *trit.Slice, trit.Matrix, *trit.Matrix:
+		if c.FormatSupported(format) {
+			return encodePlan(format)
+		}
+		return nil
+	}
+
+	if c.Next == nil {
+		return nil
+	}
+
+	return c.Next.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan implements the pgtype.Codec interface.
+func (c *Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *trit.Slice, *trit.Matrix:
+		if c.FormatSupported(format) {
+			return scanPlan(format)
+		}
+		return nil
+	}
+
+	if c.Next == nil {
+		return nil
+	}
+
+	return c.Next.PlanScan(m, oid, format, target)
+}
+
+// DecodeDatabaseSQLValue implements the pgtype.Codec interface.
+func (c *Codec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if c.Next != nil {
+		return c.Next.DecodeDatabaseSQLValue(m, oid, format, src)
+	}
+
+	var s trit.Slice
+	if err := scanPlan(format).Scan(src, &s); err != nil {
+		return nil, err
+	}
+
+	return s.Value()
+}
+
+// DecodeValue implements the pgtype.Codec interface.
+func (c *Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if c.Next != nil {
+		return c.Next.DecodeValue(m, oid, format, src)
+	}
+	if src == nil {
+		return nil, nil
+	}
+
+	var s trit.Slice
+	if err := scanPlan(format).Scan(src, &s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// encodePlan encodes trit.Slice and trit.Matrix values in its format.
+type encodePlan int16
+
+// Encode implements the pgtype.EncodePlan interface. A nil result is SQL
+// NULL.
+func (p encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	var v interface {
+		driver.Valuer
+		AppendPGBinary(buf []byte) ([]byte, error)
+	}
+
+	switch x := value.(type) {
+	case trit.Slice:
+		if x == nil {
+			return nil, nil
+		}
+		v = x
+	case *trit.Slice:
+		if x == nil || *x == nil {
+			return nil, nil
+		}
+		v = *x
+	case trit.Matrix:
+		if x == nil {
+			return nil, nil
+		}
+		v = x
+	case *trit.Matrix:
+		if x == nil || *x == nil {
+			return nil, nil
+		}
+		v = *x
+	}
+
+	if int16(p) == pgtype.BinaryFormatCode {
+		return v.AppendPGBinary(buf)
+	}
+
+	text, err := v.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, text.(string)...), nil
+}
+
+// scanPlan scans into *trit.Slice and *trit.Matrix from its format.
+type scanPlan int16
+
+// Scan implements the pgtype.ScanPlan interface.
+func (p scanPlan) Scan(src []byte, target any) error {
+	var v interface {
+		Scan(src any) error
+		ScanPGBinary(src []byte) error
+	}
+
+	switch x := target.(type) {
+	case *trit.Slice:
+		v = x
+	case *trit.Matrix:
+		v = x
+	}
+
+	switch {
+	case int16(p) == pgtype.BinaryFormatCode:
+		return v.ScanPGBinary(src)
+	case src == nil:
+		return v.Scan(nil)
+	}
+
+	return v.Scan(string(src))
+}
@@ -0,0 +1,131 @@
+package tritpgx
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/goloop/trit/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// newMap returns a pgx type map with the codec registered.
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	return m
+}
+
+// TestMatchesPgx checks that a Slice encodes to the bytes pgx's own
+// boolean[] codec writes for the same []*bool, in both formats, and that
+// those bytes scan back.
+func TestMatchesPgx(t *testing.T) {
+	yes, no := true, false
+	bools := []*bool{&yes, nil, &no}
+	s := trit.Slice{trit.True, trit.Unknown, trit.False}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		want, err := pgtype.NewMap().Encode(pgtype.BoolArrayOID, format, bools, nil)
+		if err != nil {
+			t.Fatalf("pgx Encode: %v", err)
+		}
+
+		m := newMap()
+		got, err := m.Encode(pgtype.BoolArrayOID, format, s, nil)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("format %d: Encode = %q, %v; want %q", format, got, err, want)
+		}
+
+		var back trit.Slice
+		if err := m.Scan(pgtype.BoolArrayOID, format, want, &back); err != nil ||
+			!slices.Equal(back, s) {
+			t.Errorf("format %d: Scan = %v, %v; want %v", format, back, err, s)
+		}
+	}
+}
+
+// TestMatrix checks a two-dimensional round trip in both formats.
+func TestMatrix(t *testing.T) {
+	m := newMap()
+	want := trit.Matrix{{trit.True, trit.False}, {trit.Unknown, trit.True}}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.BoolArrayOID, format, &want, nil)
+		if err != nil {
+			t.Fatalf("format %d: Encode: %v", format, err)
+		}
+
+		var got trit.Matrix
+		if err := m.Scan(pgtype.BoolArrayOID, format, buf, &got); err != nil ||
+			!slices.EqualFunc(got, want, slices.Equal) {
+			t.Errorf("format %d: round trip = %v, %v; want %v", format, got, err, want)
+		}
+	}
+}
+
+// TestNull checks that nil values encode as SQL NULL and NULL scans to nil.
+func TestNull(t *testing.T) {
+	m := newMap()
+	for _, v := range []any{trit.Slice(nil), (*trit.Slice)(nil), trit.Matrix(nil)} {
+		buf, err := m.Encode(pgtype.BoolArrayOID, pgtype.BinaryFormatCode, v, nil)
+		if err != nil || buf != nil {
+			t.Errorf("Encode(%#v) = %q, %v; want NULL", v, buf, err)
+		}
+	}
+
+	s := trit.Slice{trit.True}
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		if err := m.Scan(pgtype.BoolArrayOID, format, nil, &s); err != nil || s != nil {
+			t.Errorf("format %d: Scan(NULL) = %v, %v; want nil", format, s, err)
+		}
+	}
+}
+
+// TestOtherTypes checks that types other than trit ones still reach pgx's
+// own codec.
+func TestOtherTypes(t *testing.T) {
+	m := newMap()
+	buf, err := m.Encode(pgtype.BoolArrayOID, pgtype.BinaryFormatCode, []bool{true, false}, nil)
+	if err != nil {
+		t.Fatalf("Encode([]bool): %v", err)
+	}
+
+	var bools []bool
+	if err := m.Scan(pgtype.BoolArrayOID, pgtype.BinaryFormatCode, buf, &bools); err != nil ||
+		!slices.Equal(bools, []bool{true, false}) {
+		t.Errorf("Scan([]bool) = %v, %v", bools, err)
+	}
+
+	var s trit.Slice
+	if err := m.Scan(pgtype.BoolArrayOID, pgtype.BinaryFormatCode, buf, &s); err != nil ||
+		!slices.Equal(s, trit.Slice{trit.True, trit.False}) {
+		t.Errorf("Scan(Slice) of []bool bytes = %v, %v", s, err)
+	}
+}
+
+// TestWithoutNext checks the codec on its own, including the generic
+// decoding methods.
+func TestWithoutNext(t *testing.T) {
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "_bool", OID: pgtype.BoolArrayOID, Codec: &Codec{}})
+
+	buf, err := m.Encode(pgtype.BoolArrayOID, pgtype.BinaryFormatCode, trit.Slice{trit.Unknown}, nil)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	c := &Codec{}
+	v, err := c.DecodeValue(m, pgtype.BoolArrayOID, pgtype.BinaryFormatCode, buf)
+	if s, ok := v.(trit.Slice); err != nil || !ok || !slices.Equal(s, trit.Slice{trit.Unknown}) {
+		t.Errorf("DecodeValue = %#v, %v", v, err)
+	}
+
+	dv, err := c.DecodeDatabaseSQLValue(m, pgtype.BoolArrayOID, pgtype.BinaryFormatCode, buf)
+	if err != nil || dv != "{NULL}" {
+		t.Errorf("DecodeDatabaseSQLValue = %#v, %v; want \"{NULL}\"", dv, err)
+	}
+
+	if p := c.PlanScan(m, pgtype.BoolArrayOID, pgtype.BinaryFormatCode, new([]bool)); p != nil {
+		t.Errorf("PlanScan([]bool) without Next = %T, want nil", p)
+	}
+}
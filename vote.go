@@ -0,0 +1,138 @@
+package trit
+
+import "math"
+
+// tallyOf counts votes of any Logicable type.
+func tallyOf[T Logicable](votes []T) Tally {
+	var c Tally
+	for _, v := range votes {
+		c.Add(logicToTrit(v))
+	}
+
+	return c
+}
+
+// WeightedMajority returns True if the True votes carry more than half of
+// the total weight, False if the False votes do, and Unknown otherwise. An
+// Unknown vote adds its weight to the total without supporting either side,
+// so it can prevent a majority exactly as in Majority.
+//
+// weights[i] is the weight of votes[i]. Votes past the end of weights weigh
+// 1, so a nil weights slice makes WeightedMajority equal to Majority; extra
+// weights are ignored. Negative, NaN and infinite weights count as 0.
+//
+// Example usage:
+//
+//	votes := []trit.Trit{trit.True, trit.False, trit.False}
+//	weights := []float64{3, 1, 1}
+//	fmt.Println(trit.WeightedMajority(votes, weights)) // Output: True
+func WeightedMajority(votes []Trit, weights []float64) Trit {
+	var wt, wf, total float64
+	for i, v := range votes {
+		w := 1.0
+		if i < len(weights) {
+			w = weights[i]
+			if !(w > 0) || math.IsInf(w, 1) {
+				w = 0
+			}
+		}
+
+		total += w
+		switch v.Val() {
+		case True:
+			wt += w
+		case False:
+			wf += w
+		}
+	}
+
+	switch half := total / 2; {
+	case wt > half:
+		return True
+	case wf > half:
+		return False
+	}
+
+	return Unknown
+}
+
+// Threshold returns True if at least k votes are True, False if k True votes
+// can no longer be reached even if every Unknown vote turned True, and
+// Unknown while the outcome still depends on the Unknown votes. A k of zero
+// or less is always met.
+//
+// Example usage:
+//
+//	fmt.Println(trit.Threshold(2, trit.True, trit.True, trit.False))    // True
+//	fmt.Println(trit.Threshold(2, trit.True, trit.Unknown, trit.False)) // Unknown
+//	fmt.Println(trit.Threshold(2, trit.True, trit.False, trit.False))   // False
+func Threshold[T Logicable](k int, votes ...T) Trit {
+	c := tallyOf(votes)
+	switch {
+	case c.trues >= k:
+		return True
+	case c.trues+c.unknowns < k:
+		return False
+	}
+
+	return Unknown
+}
+
+// Supermajority returns True if at least num/den of all votes are True,
+// False if that share can no longer be reached even if every Unknown vote
+// turned True, and Unknown while the outcome still depends on the Unknown
+// votes. Note the comparison is "at least": Supermajority(2, 3, ...) passes
+// with exactly two thirds, whereas Majority requires strictly more than half.
+//
+// With no votes, or with a den of zero or less, the result is Unknown. A
+// negative num is treated as 0.
+//
+// Example usage:
+//
+//	fmt.Println(trit.Supermajority(2, 3, trit.True, trit.True, trit.False))
+//	// Output: True
+func Supermajority[T Logicable](num, den int, votes ...T) Trit {
+	if den <= 0 || len(votes) == 0 {
+		return Unknown
+	}
+	num = max(num, 0)
+
+	// Compare t/n against num/den without division.
+	c := tallyOf(votes)
+	need := int64(num) * int64(len(votes))
+	switch {
+	case int64(c.trues)*int64(den) >= need:
+		return True
+	case int64(c.trues+c.unknowns)*int64(den) < need:
+		return False
+	}
+
+	return Unknown
+}
+
+// Quorum decides by simple majority of the decided votes, provided that at
+// least q votes were cast. Unknown votes are abstentions: they count toward
+// the quorum but toward neither side. Quorum returns True if more votes are
+// True than False, False if more are False than True, and Unknown if the
+// quorum is not met or the decided votes are tied.
+//
+// Example usage:
+//
+//	votes := []trit.Trit{trit.True, trit.Unknown, trit.Unknown, trit.False, trit.True}
+//	fmt.Println(trit.Quorum(5, votes...)) // Output: True
+//	fmt.Println(trit.Quorum(6, votes...)) // Output: Unknown
+func Quorum[T Logicable](q int, votes ...T) Trit {
+	if len(votes) < q {
+		return Unknown
+	}
+
+	c := tallyOf(votes)
+	switch {
+	case c.trues > c.falses:
+		return True
+	case c.falses > c.trues:
+		return False
+	}
+
+	return Unknown
+}
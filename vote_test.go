@@ -0,0 +1,153 @@
+package trit
+
+import (
+	"math"
+	"testing"
+)
+
+// resolutions returns every way of replacing the Unknown votes in in by True
+// or False. The brute force lets the tests verify that a rule reports
+// Unknown exactly when its outcome still depends on the Unknown votes.
+func resolutions(in []Trit) [][]Trit {
+	out := [][]Trit{nil}
+	for _, v := range in {
+		var next [][]Trit
+		for _, p := range out {
+			if v != Unknown {
+				next = append(next, append(append([]Trit(nil), p...), v))
+				continue
+			}
+			next = append(next,
+				append(append([]Trit(nil), p...), True),
+				append(append([]Trit(nil), p...), False))
+		}
+		out = next
+	}
+
+	return out
+}
+
+// decided reports the common outcome of pass over every resolution of in,
+// or Unknown if the resolutions disagree.
+func decided(in []Trit, pass func([]Trit) bool) Trit {
+	var seenT, seenF bool
+	for _, r := range resolutions(in) {
+		if pass(r) {
+			seenT = true
+		} else {
+			seenF = true
+		}
+	}
+
+	switch {
+	case seenT && !seenF:
+		return True
+	case seenF && !seenT:
+		return False
+	}
+
+	return Unknown
+}
+
+func countTrue(in []Trit) int {
+	n := 0
+	for _, v := range in {
+		if v == True {
+			n++
+		}
+	}
+
+	return n
+}
+
+// TestThresholdDecided checks Threshold against the brute-force definition.
+func TestThresholdDecided(t *testing.T) {
+	for _, in := range allInputs(5) {
+		for k := -1; k <= 6; k++ {
+			want := decided(in, func(r []Trit) bool { return countTrue(r) >= k })
+			if got := Threshold(k, in...); got != want {
+				t.Errorf("Threshold(%d, %v) = %s, want %s", k, in, got, want)
+			}
+		}
+	}
+}
+
+// TestSupermajorityDecided checks Supermajority against the brute-force
+// definition for common fractions.
+func TestSupermajorityDecided(t *testing.T) {
+	fracs := [][2]int{{1, 2}, {2, 3}, {3, 4}, {3, 5}, {1, 1}, {0, 1}}
+	for _, in := range allInputs(5) {
+		for _, f := range fracs {
+			want := decided(in, func(r []Trit) bool {
+				return countTrue(r)*f[1] >= f[0]*len(r)
+			})
+			if len(in) == 0 {
+				want = Unknown
+			}
+			if got := Supermajority(f[0], f[1], in...); got != want {
+				t.Errorf("Supermajority(%d/%d, %v) = %s, want %s",
+					f[0], f[1], in, got, want)
+			}
+		}
+	}
+
+	if got := Supermajority(2, 0, True, True); got != Unknown {
+		t.Errorf("zero denominator = %s, want Unknown", got)
+	}
+	if got := Supermajority(2, 3, 1, 1, -1); got != True {
+		t.Errorf("generic ints = %s, want True", got)
+	}
+}
+
+// TestWeightedMajority covers weighting, the Majority equivalence and
+// invalid weights.
+func TestWeightedMajority(t *testing.T) {
+	for _, in := range allInputs(5) {
+		if got, want := WeightedMajority(in, nil), Majority(in...); got != want {
+			t.Errorf("WeightedMajority(%v, nil) = %s, Majority = %s", in, got, want)
+		}
+	}
+
+	cases := []struct {
+		votes   []Trit
+		weights []float64
+		want    Trit
+	}{
+		{[]Trit{True, False, False}, []float64{3, 1, 1}, True},
+		{[]Trit{True, False, False}, []float64{2, 1, 1}, Unknown},
+		{[]Trit{True, False, Unknown}, []float64{1, 1, 5}, Unknown},
+		{[]Trit{True, False}, []float64{0.4, 0.6}, False},
+		{[]Trit{True, False, False}, []float64{1, -5, math.NaN()}, True},
+		{[]Trit{True, False}, []float64{math.Inf(1), 1}, False},
+		{[]Trit{True, False, False}, []float64{3}, True}, // missing weights are 1
+		{nil, nil, Unknown},
+	}
+	for _, c := range cases {
+		if got := WeightedMajority(c.votes, c.weights); got != c.want {
+			t.Errorf("WeightedMajority(%v, %v) = %s, want %s",
+				c.votes, c.weights, got, c.want)
+		}
+	}
+}
+
+// TestQuorum checks that Unknown votes count toward the quorum only.
+func TestQuorum(t *testing.T) {
+	votes := []Trit{True, Unknown, Unknown, False, True}
+	cases := []struct {
+		q    int
+		in   []Trit
+		want Trit
+	}{
+		{5, votes, True},
+		{6, votes, Unknown},
+		{0, nil, Unknown},
+		{3, []Trit{Unknown, Unknown, False}, False},
+		{2, []Trit{True, False, Unknown}, Unknown},
+		{4, []Trit{True, True, True}, Unknown},
+	}
+	for _, c := range cases {
+		if got := Quorum(c.q, c.in...); got != c.want {
+			t.Errorf("Quorum(%d, %v) = %s, want %s", c.q, c.in, got, c.want)
+		}
+	}
+}